@@ -38,11 +38,27 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 	state.Put("hook", hook)
 	state.Put("ui", ui)
 
+	var vcenterOptions []string
+	if b.config.ConvertToTemplate {
+		vcenterOptions = append(vcenterOptions, "convert_to_template")
+	}
+	if b.config.ContentLibraryDestinationConfig != nil {
+		vcenterOptions = append(vcenterOptions, "content_library_destination")
+	}
+	if b.config.LinkedClone {
+		vcenterOptions = append(vcenterOptions, "linked_clone")
+	}
+
 	var steps []multistep.Step
 
 	steps = append(steps,
 		&common.StepConnect{
-			Config: &b.config.ConnectConfig,
+			Config:         &b.config.ConnectConfig,
+			VCenterOptions: vcenterOptions,
+		},
+		&common.StepCleanOrphanedVMs{
+			Config:   &b.config.OrphanedVMConfig,
+			Location: &b.config.LocationConfig,
 		},
 		&commonsteps.StepCreateCD{
 			Files:   b.config.CDConfig.CDFiles,
@@ -55,9 +71,10 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			SetHostForDatastoreUploads: b.config.SetHostForDatastoreUploads,
 		},
 		&StepCloneVM{
-			Config:   &b.config.CloneConfig,
-			Location: &b.config.LocationConfig,
-			Force:    b.config.PackerConfig.PackerForce,
+			Config:      &b.config.CloneConfig,
+			Location:    &b.config.LocationConfig,
+			Force:       b.config.PackerConfig.PackerForce,
+			BuildMarker: b.config.OrphanedVMAction != "",
 		},
 		&common.StepConfigureHardware{
 			Config: &b.config.HardwareConfig,
@@ -112,8 +129,12 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 				Ctx:    b.config.ctx,
 				VMName: b.config.VMName,
 			},
+			&common.StepPostInstallBootOrder{
+				Config: &b.config.RunConfig,
+			},
 			&common.StepWaitForIp{
-				Config: &b.config.WaitIpConfig,
+				Config:            &b.config.WaitIpConfig,
+				HeartbeatInterval: b.config.HeartbeatInterval,
 			},
 			&communicator.StepConnect{
 				Config:    &b.config.Comm,
@@ -121,8 +142,17 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 				SSHConfig: b.config.Comm.SSHConfigFunc(),
 			},
 			&commonsteps.StepProvision{},
+		)
+		if b.config.WindowsSysprep != nil {
+			steps = append(steps, &common.StepWindowsSysprep{
+				Config:            b.config.WindowsSysprep,
+				HeartbeatInterval: b.config.HeartbeatInterval,
+			})
+		}
+		steps = append(steps,
 			&common.StepShutdown{
-				Config: &b.config.ShutdownConfig,
+				Config:            &b.config.ShutdownConfig,
+				HeartbeatInterval: b.config.HeartbeatInterval,
 			},
 			&common.StepRemoveFloppy{
 				Datastore: b.config.Datastore,
@@ -139,6 +169,9 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			Config:      &b.config.ReattachCDRomConfig,
 			CDRomConfig: &b.config.CDRomConfig,
 		},
+		&common.StepRemoveBuildMarker{
+			Config: &b.config.OrphanedVMConfig,
+		},
 		&common.StepCreateSnapshot{
 			CreateSnapshot: b.config.CreateSnapshot,
 			SnapshotName:   b.config.SnapshotName,
@@ -156,12 +189,14 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 
 	if b.config.Export != nil {
 		steps = append(steps, &common.StepExport{
-			Name:       b.config.Export.Name,
-			Force:      b.config.Export.Force,
-			ImageFiles: b.config.Export.ImageFiles,
-			Manifest:   b.config.Export.Manifest,
-			OutputDir:  b.config.Export.OutputDir.OutputDir,
-			Options:    b.config.Export.Options,
+			Name:              b.config.Export.Name,
+			Force:             b.config.Export.Force,
+			ImageFiles:        b.config.Export.ImageFiles,
+			Manifest:          b.config.Export.Manifest,
+			OutputDir:         b.config.Export.OutputDir.OutputDir,
+			Options:           b.config.Export.Options,
+			Product:           b.config.Export.Product,
+			HeartbeatInterval: b.config.HeartbeatInterval,
 		})
 	}
 
@@ -67,6 +67,14 @@ func (c *CloneConfig) Prepare() []error {
 		errs = append(errs, fmt.Errorf("'linked_clone' and 'disk_size' cannot be used together"))
 	}
 
+	if c.LinkedClone {
+		for i, storage := range c.StorageConfig.Storage {
+			if storage.DiskEncryptionPolicy != "" {
+				errs = append(errs, fmt.Errorf("'linked_clone' and storage[%d].'disk_encryption_policy' cannot be used together", i))
+			}
+		}
+	}
+
 	if c.MacAddress != "" && c.Network == "" {
 		errs = append(errs, fmt.Errorf("'network' is required when 'mac_address' is specified"))
 	}
@@ -78,6 +86,7 @@ type StepCloneVM struct {
 	Config        *CloneConfig
 	Location      *common.LocationConfig
 	Force         bool
+	BuildMarker   bool
 	GeneratedData *packerbuilderdata.GeneratedData
 }
 
@@ -101,11 +110,21 @@ func (s *StepCloneVM) Run(ctx context.Context, state multistep.StateBag) multist
 
 	var disks []driver.Disk
 	for _, disk := range s.Config.StorageConfig.Storage {
+		var policyID string
+		if disk.DiskEncryptionPolicy != "" {
+			policyID, err = d.FindStoragePolicyID(disk.DiskEncryptionPolicy)
+			if err != nil {
+				state.Put("error", fmt.Errorf("error finding storage policy %s: %s", disk.DiskEncryptionPolicy, err))
+				return multistep.ActionHalt
+			}
+		}
 		disks = append(disks, driver.Disk{
 			DiskSize:            disk.DiskSize,
 			DiskEagerlyScrub:    disk.DiskEagerlyScrub,
 			DiskThinProvisioned: disk.DiskThinProvisioned,
 			ControllerIndex:     disk.DiskControllerIndex,
+			EncryptionPolicyID:  policyID,
+			KeyProvider:         disk.DiskKeyProvider,
 		})
 	}
 
@@ -126,6 +145,7 @@ func (s *StepCloneVM) Run(ctx context.Context, state multistep.StateBag) multist
 			DiskControllerType: s.Config.StorageConfig.DiskControllerType,
 			Storage:            disks,
 		},
+		BuildMarker: s.BuildMarker,
 	})
 	if err != nil {
 		state.Put("error", err)
@@ -70,6 +70,22 @@ func TestCreateConfig_Prepare(t *testing.T) {
 			fail:           true,
 			expectedErrMsg: "storage[0].'disk_controller_index' references an unknown disk controller",
 		},
+		{
+			name: "Storage validate disk_key_provider is set with disk_encryption_policy",
+			config: &CloneConfig{
+				StorageConfig: common.StorageConfig{
+					DiskControllerType: []string{"pvscsi"},
+					Storage: []common.DiskConfig{
+						{
+							DiskSize:             32768,
+							DiskEncryptionPolicy: "VM Encryption Policy",
+						},
+					},
+				},
+			},
+			fail:           true,
+			expectedErrMsg: "storage[0].'disk_encryption_policy' and storage[0].'disk_key_provider' must be set together",
+		},
 		{
 			name: "Validate template is set",
 			config: &CloneConfig{
@@ -103,6 +119,25 @@ func TestCreateConfig_Prepare(t *testing.T) {
 			fail:           true,
 			expectedErrMsg: "'linked_clone' and 'disk_size' cannot be used together",
 		},
+		{
+			name: "Validate LinkedClone and disk encryption set at the same time",
+			config: &CloneConfig{
+				Template:    "template name",
+				LinkedClone: true,
+				StorageConfig: common.StorageConfig{
+					DiskControllerType: []string{"test"},
+					Storage: []common.DiskConfig{
+						{
+							DiskSize:             32768,
+							DiskEncryptionPolicy: "VM Encryption Policy",
+							DiskKeyProvider:      "nkp",
+						},
+					},
+				},
+			},
+			fail:           true,
+			expectedErrMsg: "'linked_clone' and storage[0].'disk_encryption_policy' cannot be used together",
+		},
 		{
 			name: "Validate MacAddress and Network not set at the same time",
 			config: &CloneConfig{
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc struct-markdown
+//go:generate packer-sdc mapstructure-to-hcl2 -type HeartbeatConfig
+
+package common
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+type HeartbeatConfig struct {
+	// Interval at which a progress message with the elapsed time is displayed
+	// while waiting on long running operations, such as the virtual machine
+	// shutdown, the IP address or the export. This prevents CI systems with
+	// inactivity timeouts from stopping the build. Defaults to `1m`. Set to a
+	// negative duration, for example `-1s`, to disable the messages.
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+}
+
+func (c *HeartbeatConfig) Prepare() []error {
+	if c.HeartbeatInterval == 0 {
+		c.HeartbeatInterval = time.Minute
+	}
+	return nil
+}
+
+// StartHeartbeat displays a message describing the activity and the elapsed
+// time every interval until the returned function is called. A zero or
+// negative interval disables the messages.
+func StartHeartbeat(ui packersdk.Ui, interval time.Duration, activity string) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case t := <-ticker.C:
+				ui.Message(fmt.Sprintf("Still %s (%s elapsed)...", activity, t.Sub(start).Round(time.Second)))
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+			<-stopped
+		})
+	}
+}
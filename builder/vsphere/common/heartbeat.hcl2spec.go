@@ -0,0 +1,31 @@
+// Code generated by "packer-sdc mapstructure-to-hcl2"; DO NOT EDIT.
+
+package common
+
+import (
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FlatHeartbeatConfig is an auto-generated flat version of HeartbeatConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatHeartbeatConfig struct {
+	HeartbeatInterval *string `mapstructure:"heartbeat_interval" cty:"heartbeat_interval" hcl:"heartbeat_interval"`
+}
+
+// FlatMapstructure returns a new FlatHeartbeatConfig.
+// FlatHeartbeatConfig is an auto-generated flat version of HeartbeatConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*HeartbeatConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatHeartbeatConfig)
+}
+
+// HCL2Spec returns the hcl spec of a HeartbeatConfig.
+// This spec is used by HCL to read the fields of HeartbeatConfig.
+// The decoded values from this spec will then be applied to a FlatHeartbeatConfig.
+func (*FlatHeartbeatConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"heartbeat_interval": &hcldec.AttrSpec{Name: "heartbeat_interval", Type: cty.String, Required: false},
+	}
+	return s
+}
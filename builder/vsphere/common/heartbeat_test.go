@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+)
+
+func TestHeartbeatConfig_Prepare(t *testing.T) {
+	config := &HeartbeatConfig{}
+	if errs := config.Prepare(); len(errs) != 0 {
+		t.Fatalf("Config prepare should not fail: %s", errs[0])
+	}
+	if config.HeartbeatInterval != time.Minute {
+		t.Fatalf("HeartbeatInterval should default to 1m but was %s", config.HeartbeatInterval)
+	}
+
+	config = &HeartbeatConfig{HeartbeatInterval: -time.Second}
+	if errs := config.Prepare(); len(errs) != 0 {
+		t.Fatalf("Config prepare should not fail: %s", errs[0])
+	}
+	if config.HeartbeatInterval != -time.Second {
+		t.Fatalf("HeartbeatInterval should not be changed but was %s", config.HeartbeatInterval)
+	}
+}
+
+func TestStartHeartbeat(t *testing.T) {
+	writer := new(bytes.Buffer)
+	ui := &packersdk.BasicUi{
+		Reader: new(bytes.Buffer),
+		Writer: writer,
+	}
+
+	stop := StartHeartbeat(ui, 10*time.Millisecond, "waiting for test")
+	time.Sleep(50 * time.Millisecond)
+	stop()
+	stop()
+
+	output := writer.String()
+	if !strings.Contains(output, "Still waiting for test (") {
+		t.Fatalf("Expected heartbeat message but output was '%s'", output)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if writer.String() != output {
+		t.Fatalf("No heartbeat message should be displayed after stop")
+	}
+
+	writer.Reset()
+	stop = StartHeartbeat(ui, 0, "waiting for test")
+	time.Sleep(20 * time.Millisecond)
+	stop()
+	if writer.Len() != 0 {
+		t.Fatalf("No heartbeat message should be displayed when disabled but output was '%s'", writer.String())
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc struct-markdown
+//go:generate packer-sdc mapstructure-to-hcl2 -type OrphanedVMConfig
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+)
+
+const (
+	OrphanedVMActionFail    = "fail"
+	OrphanedVMActionDestroy = "destroy"
+)
+
+type OrphanedVMConfig struct {
+	// Action to take on virtual machines left behind by previous builds that
+	// did not complete, for example because Packer crashed or was killed.
+	// When set, Packer marks the virtual machine with the
+	// `packer.build.inProgress` configuration parameter while the build is
+	// in progress and removes the marker once the build completes. Before
+	// creating the virtual machine, virtual machines matching
+	// `orphaned_vm_pattern` that still carry the marker are reported.
+	// Supported values:
+	//
+	// * `fail` - Fail the build and list the orphaned virtual machines.
+	// * `destroy` - Destroy the orphaned virtual machines and continue.
+	//
+	// Defaults to unset, which disables the detection.
+	//
+	// ~> **Note:** Builds running at the same time with names matching the
+	// pattern are also considered orphaned.
+	OrphanedVMAction string `mapstructure:"orphaned_vm_action"`
+	// Name pattern of the virtual machines in `folder` to check for orphans.
+	// Supports the `*` and `?` wildcards. For example, `packer-ubuntu-*`.
+	// Defaults to `vm_name`.
+	OrphanedVMPattern string `mapstructure:"orphaned_vm_pattern"`
+}
+
+func (c *OrphanedVMConfig) Prepare(location *LocationConfig) []error {
+	var errs []error
+
+	switch c.OrphanedVMAction {
+	case "":
+		return errs
+	case OrphanedVMActionFail, OrphanedVMActionDestroy:
+	default:
+		errs = append(errs, fmt.Errorf("'orphaned_vm_action' must be '%s' or '%s'", OrphanedVMActionFail, OrphanedVMActionDestroy))
+	}
+
+	if c.OrphanedVMPattern == "" {
+		c.OrphanedVMPattern = location.VMName
+	}
+	if _, err := path.Match(c.OrphanedVMPattern, ""); err != nil || strings.Contains(c.OrphanedVMPattern, "/") {
+		errs = append(errs, fmt.Errorf("'orphaned_vm_pattern' must be a valid virtual machine name pattern"))
+	}
+
+	return errs
+}
+
+type StepCleanOrphanedVMs struct {
+	Config   *OrphanedVMConfig
+	Location *LocationConfig
+}
+
+func (s *StepCleanOrphanedVMs) Run(_ context.Context, state multistep.StateBag) multistep.StepAction {
+	if s.Config.OrphanedVMAction == "" {
+		return multistep.ActionContinue
+	}
+
+	ui := state.Get("ui").(packersdk.Ui)
+	d := state.Get("driver").(driver.Driver)
+
+	ui.Say("Looking for orphaned virtual machines...")
+	vms, err := d.FindVMs(path.Join(s.Location.Folder, s.Config.OrphanedVMPattern))
+	if err != nil {
+		state.Put("error", fmt.Errorf("error looking up orphaned virtual machines: %s", err))
+		return multistep.ActionHalt
+	}
+
+	var orphans []driver.VirtualMachine
+	var names []string
+	for _, vm := range vms {
+		marked, err := vm.HasBuildMarker()
+		if err != nil {
+			state.Put("error", fmt.Errorf("error reading configuration of %s: %s", vm.Name(), err))
+			return multistep.ActionHalt
+		}
+		if marked {
+			orphans = append(orphans, vm)
+			names = append(names, vm.Name())
+		}
+	}
+
+	if len(orphans) == 0 {
+		return multistep.ActionContinue
+	}
+
+	if s.Config.OrphanedVMAction == OrphanedVMActionFail {
+		state.Put("error", fmt.Errorf("found orphaned virtual machines from previous builds: %s", strings.Join(names, ", ")))
+		return multistep.ActionHalt
+	}
+
+	for _, vm := range orphans {
+		ui.Say(fmt.Sprintf("Destroying orphaned virtual machine %s...", vm.Name()))
+
+		// power off just in case it is still on
+		_ = vm.PowerOff()
+		if err := vm.Destroy(); err != nil {
+			state.Put("error", fmt.Errorf("error destroying orphaned virtual machine %s: %s", vm.Name(), err))
+			return multistep.ActionHalt
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepCleanOrphanedVMs) Cleanup(multistep.StateBag) {}
+
+type StepRemoveBuildMarker struct {
+	Config *OrphanedVMConfig
+}
+
+func (s *StepRemoveBuildMarker) Run(_ context.Context, state multistep.StateBag) multistep.StepAction {
+	if s.Config.OrphanedVMAction == "" {
+		return multistep.ActionContinue
+	}
+
+	ui := state.Get("ui").(packersdk.Ui)
+	vm := state.Get("vm").(driver.VirtualMachine)
+
+	ui.Say("Removing build marker...")
+	// An empty value removes the key from the virtual machine configuration.
+	if err := vm.AddConfigParams(map[string]string{driver.BuildMarkerKey: ""}, nil); err != nil {
+		state.Put("error", fmt.Errorf("error removing build marker: %s", err))
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepRemoveBuildMarker) Cleanup(multistep.StateBag) {}
@@ -0,0 +1,33 @@
+// Code generated by "packer-sdc mapstructure-to-hcl2"; DO NOT EDIT.
+
+package common
+
+import (
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FlatOrphanedVMConfig is an auto-generated flat version of OrphanedVMConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatOrphanedVMConfig struct {
+	OrphanedVMAction  *string `mapstructure:"orphaned_vm_action" cty:"orphaned_vm_action" hcl:"orphaned_vm_action"`
+	OrphanedVMPattern *string `mapstructure:"orphaned_vm_pattern" cty:"orphaned_vm_pattern" hcl:"orphaned_vm_pattern"`
+}
+
+// FlatMapstructure returns a new FlatOrphanedVMConfig.
+// FlatOrphanedVMConfig is an auto-generated flat version of OrphanedVMConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*OrphanedVMConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatOrphanedVMConfig)
+}
+
+// HCL2Spec returns the hcl spec of a OrphanedVMConfig.
+// This spec is used by HCL to read the fields of OrphanedVMConfig.
+// The decoded values from this spec will then be applied to a FlatOrphanedVMConfig.
+func (*FlatOrphanedVMConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"orphaned_vm_action":  &hcldec.AttrSpec{Name: "orphaned_vm_action", Type: cty.String, Required: false},
+		"orphaned_vm_pattern": &hcldec.AttrSpec{Name: "orphaned_vm_pattern", Type: cty.String, Required: false},
+	}
+	return s
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+)
+
+func TestOrphanedVMConfig_Prepare(t *testing.T) {
+	tc := []struct {
+		name            string
+		config          *OrphanedVMConfig
+		fail            bool
+		expectedErrMsg  string
+		expectedPattern string
+	}{
+		{
+			name:   "Validate empty config",
+			config: &OrphanedVMConfig{},
+			fail:   false,
+		},
+		{
+			name:            "Default pattern to vm_name",
+			config:          &OrphanedVMConfig{OrphanedVMAction: "fail"},
+			fail:            false,
+			expectedPattern: "packer-vm",
+		},
+		{
+			name: "Keep custom pattern",
+			config: &OrphanedVMConfig{
+				OrphanedVMAction:  "destroy",
+				OrphanedVMPattern: "packer-*",
+			},
+			fail:            false,
+			expectedPattern: "packer-*",
+		},
+		{
+			name:           "Invalid action",
+			config:         &OrphanedVMConfig{OrphanedVMAction: "ignore"},
+			fail:           true,
+			expectedErrMsg: "'orphaned_vm_action' must be 'fail' or 'destroy'",
+		},
+		{
+			name: "Invalid pattern",
+			config: &OrphanedVMConfig{
+				OrphanedVMAction:  "fail",
+				OrphanedVMPattern: "packer-[",
+			},
+			fail:           true,
+			expectedErrMsg: "'orphaned_vm_pattern' must be a valid virtual machine name pattern",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			errs := c.config.Prepare(&LocationConfig{VMName: "packer-vm"})
+			if c.fail {
+				if len(errs) == 0 {
+					t.Fatalf("Config prepare should fail")
+				}
+				if errs[0].Error() != c.expectedErrMsg {
+					t.Fatalf("Expected error message: %s but was '%s'", c.expectedErrMsg, errs[0].Error())
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Fatalf("Config prepare should not fail: %s", errs[0])
+			}
+			if c.config.OrphanedVMPattern != c.expectedPattern {
+				t.Fatalf("unexpected pattern %q", c.config.OrphanedVMPattern)
+			}
+		})
+	}
+}
+
+func TestStepCleanOrphanedVMs_Run(t *testing.T) {
+	tc := []struct {
+		name              string
+		action            string
+		vms               []*driver.VirtualMachineMock
+		expectedAction    multistep.StepAction
+		expectedDestroyed []bool
+		errMessage        string
+	}{
+		{
+			name:           "Skip when disabled",
+			action:         "",
+			expectedAction: multistep.ActionContinue,
+		},
+		{
+			name:   "Ignore virtual machines without marker",
+			action: "fail",
+			vms: []*driver.VirtualMachineMock{
+				{NameReturn: "packer-1"},
+			},
+			expectedAction:    multistep.ActionContinue,
+			expectedDestroyed: []bool{false},
+		},
+		{
+			name:   "Fail with the list of orphans",
+			action: "fail",
+			vms: []*driver.VirtualMachineMock{
+				{NameReturn: "packer-1", HasBuildMarkerReturn: true},
+				{NameReturn: "packer-2"},
+				{NameReturn: "packer-3", HasBuildMarkerReturn: true},
+			},
+			expectedAction:    multistep.ActionHalt,
+			expectedDestroyed: []bool{false, false, false},
+			errMessage:        "found orphaned virtual machines from previous builds: packer-1, packer-3",
+		},
+		{
+			name:   "Destroy orphans",
+			action: "destroy",
+			vms: []*driver.VirtualMachineMock{
+				{NameReturn: "packer-1", HasBuildMarkerReturn: true},
+				{NameReturn: "packer-2"},
+			},
+			expectedAction:    multistep.ActionContinue,
+			expectedDestroyed: []bool{true, false},
+		},
+		{
+			name:   "Fail to destroy orphan",
+			action: "destroy",
+			vms: []*driver.VirtualMachineMock{
+				{NameReturn: "packer-1", HasBuildMarkerReturn: true, DestroyError: fmt.Errorf("destroy failed")},
+			},
+			expectedAction:    multistep.ActionHalt,
+			expectedDestroyed: []bool{true},
+			errMessage:        "error destroying orphaned virtual machine packer-1: destroy failed",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			d := driver.NewDriverMock()
+			for _, vm := range c.vms {
+				d.FindVMsResponse = append(d.FindVMsResponse, vm)
+			}
+			state := basicStateBag(nil)
+			state.Put("driver", d)
+
+			step := &StepCleanOrphanedVMs{
+				Config: &OrphanedVMConfig{
+					OrphanedVMAction:  c.action,
+					OrphanedVMPattern: "packer-*",
+				},
+				Location: &LocationConfig{Folder: "templates"},
+			}
+			if action := step.Run(context.TODO(), state); action != c.expectedAction {
+				t.Fatalf("unexpected action %v", action)
+			}
+
+			err, ok := state.Get("error").(error)
+			if ok {
+				if err.Error() != c.errMessage {
+					t.Fatalf("unexpected error %s", err.Error())
+				}
+			} else if c.errMessage != "" {
+				t.Fatalf("expected to fail but it didn't")
+			}
+
+			if c.action == "" {
+				if d.FindVMsCalled {
+					t.Fatalf("FindVMs should not be called")
+				}
+				return
+			}
+			if d.FindVMsPattern != "templates/packer-*" {
+				t.Fatalf("unexpected pattern %q", d.FindVMsPattern)
+			}
+
+			var destroyed []bool
+			for _, vm := range c.vms {
+				destroyed = append(destroyed, vm.DestroyCalled)
+			}
+			if diff := cmp.Diff(destroyed, c.expectedDestroyed); diff != "" {
+				t.Fatalf("unexpected destroyed virtual machines: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStepRemoveBuildMarker_Run(t *testing.T) {
+	vm := new(driver.VirtualMachineMock)
+	state := basicStateBag(nil)
+	state.Put("vm", vm)
+
+	step := &StepRemoveBuildMarker{Config: &OrphanedVMConfig{}}
+	if action := step.Run(context.TODO(), state); action != multistep.ActionContinue {
+		t.Fatalf("unexpected action %v", action)
+	}
+	if vm.AddConfigParamsCalled {
+		t.Fatalf("AddConfigParams should not be called when detection is disabled")
+	}
+
+	step.Config.OrphanedVMAction = "destroy"
+	if action := step.Run(context.TODO(), state); action != multistep.ActionContinue {
+		t.Fatalf("unexpected action %v", action)
+	}
+	expected := map[string]string{driver.BuildMarkerKey: ""}
+	if diff := cmp.Diff(vm.AddConfigParamsParams, expected); diff != "" {
+		t.Fatalf("unexpected config params: %s", diff)
+	}
+}
@@ -17,13 +17,17 @@ import (
 )
 
 type ConnectConfig struct {
-	// vCenter Server hostname.
+	// vCenter Server hostname, or the hostname of a standalone ESXi host. A
+	// standalone ESXi host supports a reduced set of features. Refer to
+	// [Standalone ESXi Host](#standalone-esxi-host) for details.
 	VCenterServer string `mapstructure:"vcenter_server"`
 	// vSphere username.
 	Username string `mapstructure:"username"`
 	// vSphere password.
 	Password string `mapstructure:"password"`
 	// Do not validate the vCenter Server TLS certificate. Defaults to `false`.
+	// With `iso_content_library`, this also has vCenter Server accept an
+	// untrusted certificate of the server from which it downloads the ISO.
 	InsecureConnection bool `mapstructure:"insecure_connection"`
 	// vSphere datacenter name. Required if there is more than one datacenter in the vSphere inventory.
 	Datacenter string `mapstructure:"datacenter"`
@@ -47,9 +51,15 @@ func (c *ConnectConfig) Prepare() []error {
 
 type StepConnect struct {
 	Config *ConnectConfig
+	// The options set in the configuration that require vCenter Server. The
+	// build halts before the virtual machine is created when connected to a
+	// standalone ESXi host.
+	VCenterOptions []string
 }
 
 func (s *StepConnect) Run(_ context.Context, state multistep.StateBag) multistep.StepAction {
+	ui := state.Get("ui").(packersdk.Ui)
+
 	d, err := driver.NewDriver(&driver.ConnectConfig{
 		VCenterServer:      s.Config.VCenterServer,
 		Username:           s.Config.Username,
@@ -63,6 +73,14 @@ func (s *StepConnect) Run(_ context.Context, state multistep.StateBag) multistep
 	}
 	state.Put("driver", d)
 
+	if d.IsStandaloneHost() {
+		for _, option := range s.VCenterOptions {
+			state.Put("error", fmt.Errorf("'%s' is not supported on a standalone ESXi host", option))
+			return multistep.ActionHalt
+		}
+		ui.Say("Connected to a standalone ESXi host. Folders, content libraries and templates are not supported.")
+	}
+
 	return multistep.ActionContinue
 }
 
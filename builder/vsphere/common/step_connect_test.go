@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/vmware/govmomi/simulator"
+)
+
+func TestStepConnect_Run_StandaloneHost(t *testing.T) {
+	sim, err := NewCustomVCenterSimulator(simulator.ESX())
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	tc := []struct {
+		name           string
+		vcenterOptions []string
+		expectedAction multistep.StepAction
+		errMessage     string
+	}{
+		{
+			name:           "No vCenter Server options",
+			expectedAction: multistep.ActionContinue,
+		},
+		{
+			name:           "Convert to template",
+			vcenterOptions: []string{"convert_to_template", "content_library_destination"},
+			expectedAction: multistep.ActionHalt,
+			errMessage:     "'convert_to_template' is not supported on a standalone ESXi host",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			state := basicStateBag(nil)
+			step := &StepConnect{
+				Config: &ConnectConfig{
+					VCenterServer:      sim.server.URL.Host,
+					InsecureConnection: true,
+				},
+				VCenterOptions: c.vcenterOptions,
+			}
+			if action := step.Run(context.TODO(), state); action != c.expectedAction {
+				t.Fatalf("unexpected action %v", action)
+			}
+			err, ok := state.Get("error").(error)
+			if ok {
+				if err.Error() != c.errMessage {
+					t.Fatalf("unexpected error %s", err.Error())
+				}
+			} else if c.errMessage != "" {
+				t.Fatalf("expected to fail but it didn't")
+			}
+		})
+	}
+}
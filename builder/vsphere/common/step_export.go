@@ -2,7 +2,7 @@
 // SPDX-License-Identifier: MPL-2.0
 
 //go:generate packer-sdc struct-markdown
-//go:generate packer-sdc mapstructure-to-hcl2 -type ExportConfig
+//go:generate packer-sdc mapstructure-to-hcl2 -type ExportConfig,ExportProductConfig,ExportPropertyConfig
 
 package common
 
@@ -12,12 +12,15 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/xml"
 	"fmt"
 	"hash"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/common"
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
@@ -105,6 +108,136 @@ type ExportConfig struct {
 	//   }
 	// ```
 	Options []string `mapstructure:"options"`
+	// Product information and properties to write to a `ProductSection` of the
+	// OVF descriptor. See the [Export Product Configuration](#export-product-configuration).
+	Product *ExportProductConfig `mapstructure:"product"`
+}
+
+// Describes the product and the properties written to a `ProductSection` of
+// the exported Open Virtualization Format (OVF) descriptor. Properties marked
+// as user configurable are prompted for when the OVF is deployed.
+//
+// When the descriptor already contains a `ProductSection`, such as the one
+// written for the vApp options of the VM, the product information and
+// properties are merged into it. The export fails if a property key or a
+// product information element is already defined in the existing section.
+//
+// Example usage:
+//
+// In JSON:
+// ```json
+//
+//	"export": {
+//	  "product": {
+//	    "product": "Example Appliance",
+//	    "vendor": "Example, Inc.",
+//	    "version": "1.0.0",
+//	    "property": [
+//	      {
+//	        "key": "hostname",
+//	        "label": "Hostname",
+//	        "value": "appliance",
+//	        "user_configurable": true
+//	      }
+//	    ]
+//	  }
+//	},
+//
+// ```
+// In HCL2:
+// ```hcl
+//
+//	export {
+//	  product {
+//	    product = "Example Appliance"
+//	    vendor  = "Example, Inc."
+//	    version = "1.0.0"
+//	    property {
+//	      key               = "hostname"
+//	      label             = "Hostname"
+//	      value             = "appliance"
+//	      user_configurable = true
+//	    }
+//	  }
+//	}
+//
+// ```
+type ExportProductConfig struct {
+	// Name of the product.
+	Product string `mapstructure:"product"`
+	// Name of the product vendor.
+	Vendor string `mapstructure:"vendor"`
+	// Short version of the product. For example, `1.0`.
+	Version string `mapstructure:"version"`
+	// Full version of the product. For example, `1.0.0-build.42`.
+	FullVersion string `mapstructure:"full_version"`
+	// URL of the product.
+	ProductURL string `mapstructure:"product_url"`
+	// URL of the product vendor.
+	VendorURL string `mapstructure:"vendor_url"`
+	// Properties of the product. See the [Export Property Configuration](#export-property-configuration).
+	Properties []ExportPropertyConfig `mapstructure:"property"`
+}
+
+type ExportPropertyConfig struct {
+	// Key of the property.
+	Key string `mapstructure:"key" required:"true"`
+	// Type of the property. Available options include `string`, `boolean`,
+	// `uint8`, `sint8`, `uint16`, `sint16`, `uint32`, `sint32`, `uint64`,
+	// `sint64`, `real32` and `real64`. Defaults to `string`.
+	Type string `mapstructure:"type"`
+	// Constraints on the value of the property. For example, `MinLen(1)` or
+	// `ValueMap{"small","large"}`.
+	Qualifiers string `mapstructure:"qualifiers"`
+	// Default value of the property.
+	Value string `mapstructure:"value"`
+	// Allow the value of the property to be set when the OVF is deployed.
+	// Defaults to `false`.
+	UserConfigurable bool `mapstructure:"user_configurable"`
+	// Hide the value of the property when it is entered. Defaults to `false`.
+	Password bool `mapstructure:"password"`
+	// Short label of the property.
+	Label string `mapstructure:"label"`
+	// Description of the property.
+	Description string `mapstructure:"description"`
+}
+
+// Supported property types.
+var ovfPropertyTypes = []string{
+	"string", "boolean",
+	"uint8", "sint8", "uint16", "sint16", "uint32", "sint32", "uint64", "sint64",
+	"real32", "real64",
+}
+
+func (c *ExportProductConfig) Prepare() []error {
+	var errs []error
+
+	keys := make(map[string]bool)
+	for i := range c.Properties {
+		p := &c.Properties[i]
+		if p.Key == "" {
+			errs = append(errs, fmt.Errorf("'key' is required for 'property[%d]'", i))
+		} else if keys[p.Key] {
+			errs = append(errs, fmt.Errorf("duplicate property key: %s", p.Key))
+		}
+		keys[p.Key] = true
+
+		if p.Type == "" {
+			p.Type = "string"
+		}
+		supported := false
+		for _, t := range ovfPropertyTypes {
+			if p.Type == t {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			errs = append(errs, fmt.Errorf("unsupported type for property %s: %s", p.Key, p.Type))
+		}
+	}
+
+	return errs
 }
 
 // Supported hash algorithms.
@@ -130,6 +263,10 @@ func (c *ExportConfig) Prepare(ctx *interpolate.Context, lc *LocationConfig, pc
 		errs = packersdk.MultiErrorAppend(errs, fmt.Errorf("unsupported hash: %s. available options include 'none', 'sha1', 'sha256', and 'sha512'", c.Manifest))
 	}
 
+	if c.Product != nil {
+		errs = packersdk.MultiErrorAppend(errs, c.Product.Prepare()...)
+	}
+
 	// Default the name to the name of the virtual machine if not specified.
 	if c.Name == "" {
 		c.Name = lc.VMName
@@ -152,19 +289,178 @@ func (c *ExportConfig) Prepare(ctx *interpolate.Context, lc *LocationConfig, pc
 	return nil
 }
 
+type ovfProductSection struct {
+	XMLName     xml.Name             `xml:"ProductSection"`
+	Info        string               `xml:"Info"`
+	Product     string               `xml:"Product,omitempty"`
+	Vendor      string               `xml:"Vendor,omitempty"`
+	Version     string               `xml:"Version,omitempty"`
+	FullVersion string               `xml:"FullVersion,omitempty"`
+	ProductURL  string               `xml:"ProductUrl,omitempty"`
+	VendorURL   string               `xml:"VendorUrl,omitempty"`
+	Properties  []ovfProductProperty `xml:"Property"`
+}
+
+type ovfProductProperty struct {
+	XMLName          xml.Name `xml:"Property"`
+	Key              string   `xml:"ovf:key,attr"`
+	Type             string   `xml:"ovf:type,attr"`
+	Qualifiers       string   `xml:"ovf:qualifiers,attr,omitempty"`
+	UserConfigurable bool     `xml:"ovf:userConfigurable,attr"`
+	Value            string   `xml:"ovf:value,attr"`
+	Password         bool     `xml:"ovf:password,attr,omitempty"`
+	Label            string   `xml:"Label,omitempty"`
+	Description      string   `xml:"Description,omitempty"`
+}
+
+// Adds a ProductSection built from the product configuration to the
+// VirtualSystem of the Open Virtualization Format (OVF) descriptor. When the
+// descriptor already contains a ProductSection without a class, such as the
+// one written for the vApp options of the VM, the product configuration is
+// merged into it, as the descriptor cannot contain two sections with the same
+// class.
+func addProductSection(descriptor string, product *ExportProductConfig) (string, error) {
+	section := ovfProductSection{
+		Info:        "Information about the installed software",
+		Product:     product.Product,
+		Vendor:      product.Vendor,
+		Version:     product.Version,
+		FullVersion: product.FullVersion,
+		ProductURL:  product.ProductURL,
+		VendorURL:   product.VendorURL,
+	}
+	for _, p := range product.Properties {
+		section.Properties = append(section.Properties, ovfProductProperty{
+			Key:              p.Key,
+			Type:             p.Type,
+			Qualifiers:       p.Qualifiers,
+			UserConfigurable: p.UserConfigurable,
+			Value:            p.Value,
+			Password:         p.Password,
+			Label:            p.Label,
+			Description:      p.Description,
+		})
+	}
+
+	end := strings.LastIndex(descriptor, "</VirtualSystem>")
+	if end < 0 {
+		return "", fmt.Errorf("unable to find the virtual system in the ovf descriptor")
+	}
+
+	if start, stop, ok := findProductSection(descriptor[:end]); ok {
+		return mergeProductSection(descriptor, start, stop, section)
+	}
+
+	// Insert the section at the beginning of the line of the closing tag.
+	lineStart, indent := lineIndent(descriptor, end)
+
+	b, err := xml.MarshalIndent(section, indent+"  ", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return descriptor[:lineStart] + string(b) + "\n" + descriptor[lineStart:], nil
+}
+
+var productSectionPattern = regexp.MustCompile(`(?s)<ProductSection(\s[^>]*)?>.*?</ProductSection>`)
+
+// Returns the position of the first ProductSection without a class.
+func findProductSection(descriptor string) (int, int, bool) {
+	for _, match := range productSectionPattern.FindAllStringSubmatchIndex(descriptor, -1) {
+		if match[2] >= 0 && strings.Contains(descriptor[match[2]:match[3]], "class=") {
+			continue
+		}
+		return match[0], match[1], true
+	}
+	return 0, 0, false
+}
+
+// Merges the product information and the properties into the existing
+// ProductSection found between start and stop.
+func mergeProductSection(descriptor string, start int, stop int, section ovfProductSection) (string, error) {
+	existing := descriptor[start:stop]
+	_, indent := lineIndent(descriptor, start)
+	indent += "  "
+
+	var info strings.Builder
+	for _, element := range []struct {
+		name  string
+		value string
+	}{
+		{"Product", section.Product},
+		{"Vendor", section.Vendor},
+		{"Version", section.Version},
+		{"FullVersion", section.FullVersion},
+		{"ProductUrl", section.ProductURL},
+		{"VendorUrl", section.VendorURL},
+	} {
+		if element.value == "" {
+			continue
+		}
+		if strings.Contains(existing, "<"+element.name+">") {
+			return "", fmt.Errorf("the product section of the ovf descriptor already contains %s information", element.name)
+		}
+		info.WriteString("\n" + indent + "<" + element.name + ">")
+		if err := xml.EscapeText(&info, []byte(element.value)); err != nil {
+			return "", err
+		}
+		info.WriteString("</" + element.name + ">")
+	}
+
+	var properties strings.Builder
+	for _, p := range section.Properties {
+		if strings.Contains(existing, fmt.Sprintf(`ovf:key="%s"`, p.Key)) {
+			return "", fmt.Errorf("the product section of the ovf descriptor already contains the property %s", p.Key)
+		}
+		b, err := xml.MarshalIndent(p, indent, "  ")
+		if err != nil {
+			return "", err
+		}
+		properties.WriteString(indent + strings.TrimLeft(string(b), " ") + "\n")
+	}
+
+	merged := existing
+	if info.Len() > 0 {
+		infoEnd := strings.Index(merged, "</Info>")
+		if infoEnd < 0 {
+			return "", fmt.Errorf("unable to find the information of the product section in the ovf descriptor")
+		}
+		infoEnd += len("</Info>")
+		merged = merged[:infoEnd] + info.String() + merged[infoEnd:]
+	}
+	closing := strings.LastIndex(merged, "</ProductSection>")
+	lineStart, _ := lineIndent(merged, closing)
+	merged = merged[:lineStart] + properties.String() + merged[lineStart:]
+
+	return descriptor[:start] + merged + descriptor[stop:], nil
+}
+
+// Returns the beginning and the indentation of the line of the position, or
+// the position itself when the line has other content before it.
+func lineIndent(descriptor string, pos int) (int, string) {
+	lineStart := strings.LastIndex(descriptor[:pos], "\n") + 1
+	indent := descriptor[lineStart:pos]
+	if strings.TrimSpace(indent) != "" {
+		return pos, ""
+	}
+	return lineStart, indent
+}
+
 // Returns the target path for the exported image in Open Virtualization Format (OVF).
 func getTarget(dir string, name string) string {
 	return filepath.Join(dir, name+".ovf")
 }
 
 type StepExport struct {
-	Name       string
-	Force      bool
-	ImageFiles bool
-	Manifest   string
-	OutputDir  string
-	Options    []string
-	mf         bytes.Buffer
+	Name              string
+	Force             bool
+	ImageFiles        bool
+	Manifest          string
+	OutputDir         string
+	Options           []string
+	Product           *ExportProductConfig
+	HeartbeatInterval time.Duration
+	mf                bytes.Buffer
 }
 
 func (s *StepExport) Cleanup(multistep.StateBag) {
@@ -236,7 +532,9 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 
 		// Download the virtual machine image in Open Virtualization Format (OVF).
 		ui.Say(fmt.Sprintf("Downloading %s...", file.Path))
+		stop := StartHeartbeat(ui, s.HeartbeatInterval, fmt.Sprintf("downloading %s", file.Path))
 		size, err := s.Download(ctx, lease, i)
+		stop()
 		if err != nil {
 			state.Put("error", err)
 			return multistep.ActionHalt
@@ -261,6 +559,15 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 		return multistep.ActionHalt
 	}
 
+	descriptor := desc.OvfDescriptor
+	if s.Product != nil {
+		descriptor, err = addProductSection(descriptor, s.Product)
+		if err != nil {
+			state.Put("error", errors.Wrap(err, "unable to add product section to descriptor"))
+			return multistep.ActionHalt
+		}
+	}
+
 	target := getTarget(s.OutputDir, s.Name)
 	file, err := os.Create(target)
 	if err != nil {
@@ -276,7 +583,7 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 
 	// Write the Open Virtualization Format descriptor.
 	ui.Say(fmt.Sprintf("Writing OVF descriptor %s...", s.Name+".ovf"))
-	_, err = io.WriteString(w, desc.OvfDescriptor)
+	_, err = io.WriteString(w, descriptor)
 	if err != nil {
 		state.Put("error", errors.Wrap(err, "unable to write ovf descriptor"))
 		return multistep.ActionHalt
@@ -12,13 +12,14 @@ import (
 // FlatExportConfig is an auto-generated flat version of ExportConfig.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatExportConfig struct {
-	Name       *string      `mapstructure:"name" cty:"name" hcl:"name"`
-	Force      *bool        `mapstructure:"force" cty:"force" hcl:"force"`
-	ImageFiles *bool        `mapstructure:"image_files" cty:"image_files" hcl:"image_files"`
-	Manifest   *string      `mapstructure:"manifest" cty:"manifest" hcl:"manifest"`
-	OutputDir  *string      `mapstructure:"output_directory" required:"false" cty:"output_directory" hcl:"output_directory"`
-	DirPerm    *fs.FileMode `mapstructure:"directory_permission" required:"false" cty:"directory_permission" hcl:"directory_permission"`
-	Options    []string     `mapstructure:"options" cty:"options" hcl:"options"`
+	Name       *string                  `mapstructure:"name" cty:"name" hcl:"name"`
+	Force      *bool                    `mapstructure:"force" cty:"force" hcl:"force"`
+	ImageFiles *bool                    `mapstructure:"image_files" cty:"image_files" hcl:"image_files"`
+	Manifest   *string                  `mapstructure:"manifest" cty:"manifest" hcl:"manifest"`
+	OutputDir  *string                  `mapstructure:"output_directory" required:"false" cty:"output_directory" hcl:"output_directory"`
+	DirPerm    *fs.FileMode             `mapstructure:"directory_permission" required:"false" cty:"directory_permission" hcl:"directory_permission"`
+	Options    []string                 `mapstructure:"options" cty:"options" hcl:"options"`
+	Product    *FlatExportProductConfig `mapstructure:"product" cty:"product" hcl:"product"`
 }
 
 // FlatMapstructure returns a new FlatExportConfig.
@@ -40,6 +41,79 @@ func (*FlatExportConfig) HCL2Spec() map[string]hcldec.Spec {
 		"output_directory":     &hcldec.AttrSpec{Name: "output_directory", Type: cty.String, Required: false},
 		"directory_permission": &hcldec.AttrSpec{Name: "directory_permission", Type: cty.Number, Required: false},
 		"options":              &hcldec.AttrSpec{Name: "options", Type: cty.List(cty.String), Required: false},
+		"product":              &hcldec.BlockSpec{TypeName: "product", Nested: hcldec.ObjectSpec((*FlatExportProductConfig)(nil).HCL2Spec())},
+	}
+	return s
+}
+
+// FlatExportProductConfig is an auto-generated flat version of ExportProductConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatExportProductConfig struct {
+	Product     *string                    `mapstructure:"product" cty:"product" hcl:"product"`
+	Vendor      *string                    `mapstructure:"vendor" cty:"vendor" hcl:"vendor"`
+	Version     *string                    `mapstructure:"version" cty:"version" hcl:"version"`
+	FullVersion *string                    `mapstructure:"full_version" cty:"full_version" hcl:"full_version"`
+	ProductURL  *string                    `mapstructure:"product_url" cty:"product_url" hcl:"product_url"`
+	VendorURL   *string                    `mapstructure:"vendor_url" cty:"vendor_url" hcl:"vendor_url"`
+	Properties  []FlatExportPropertyConfig `mapstructure:"property" cty:"property" hcl:"property"`
+}
+
+// FlatMapstructure returns a new FlatExportProductConfig.
+// FlatExportProductConfig is an auto-generated flat version of ExportProductConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*ExportProductConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatExportProductConfig)
+}
+
+// HCL2Spec returns the hcl spec of a ExportProductConfig.
+// This spec is used by HCL to read the fields of ExportProductConfig.
+// The decoded values from this spec will then be applied to a FlatExportProductConfig.
+func (*FlatExportProductConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"product":      &hcldec.AttrSpec{Name: "product", Type: cty.String, Required: false},
+		"vendor":       &hcldec.AttrSpec{Name: "vendor", Type: cty.String, Required: false},
+		"version":      &hcldec.AttrSpec{Name: "version", Type: cty.String, Required: false},
+		"full_version": &hcldec.AttrSpec{Name: "full_version", Type: cty.String, Required: false},
+		"product_url":  &hcldec.AttrSpec{Name: "product_url", Type: cty.String, Required: false},
+		"vendor_url":   &hcldec.AttrSpec{Name: "vendor_url", Type: cty.String, Required: false},
+		"property":     &hcldec.BlockListSpec{TypeName: "property", Nested: hcldec.ObjectSpec((*FlatExportPropertyConfig)(nil).HCL2Spec())},
+	}
+	return s
+}
+
+// FlatExportPropertyConfig is an auto-generated flat version of ExportPropertyConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatExportPropertyConfig struct {
+	Key              *string `mapstructure:"key" required:"true" cty:"key" hcl:"key"`
+	Type             *string `mapstructure:"type" cty:"type" hcl:"type"`
+	Qualifiers       *string `mapstructure:"qualifiers" cty:"qualifiers" hcl:"qualifiers"`
+	Value            *string `mapstructure:"value" cty:"value" hcl:"value"`
+	UserConfigurable *bool   `mapstructure:"user_configurable" cty:"user_configurable" hcl:"user_configurable"`
+	Password         *bool   `mapstructure:"password" cty:"password" hcl:"password"`
+	Label            *string `mapstructure:"label" cty:"label" hcl:"label"`
+	Description      *string `mapstructure:"description" cty:"description" hcl:"description"`
+}
+
+// FlatMapstructure returns a new FlatExportPropertyConfig.
+// FlatExportPropertyConfig is an auto-generated flat version of ExportPropertyConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*ExportPropertyConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatExportPropertyConfig)
+}
+
+// HCL2Spec returns the hcl spec of a ExportPropertyConfig.
+// This spec is used by HCL to read the fields of ExportPropertyConfig.
+// The decoded values from this spec will then be applied to a FlatExportPropertyConfig.
+func (*FlatExportPropertyConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"key":               &hcldec.AttrSpec{Name: "key", Type: cty.String, Required: false},
+		"type":              &hcldec.AttrSpec{Name: "type", Type: cty.String, Required: false},
+		"qualifiers":        &hcldec.AttrSpec{Name: "qualifiers", Type: cty.String, Required: false},
+		"value":             &hcldec.AttrSpec{Name: "value", Type: cty.String, Required: false},
+		"user_configurable": &hcldec.AttrSpec{Name: "user_configurable", Type: cty.Bool, Required: false},
+		"password":          &hcldec.AttrSpec{Name: "password", Type: cty.Bool, Required: false},
+		"label":             &hcldec.AttrSpec{Name: "label", Type: cty.String, Required: false},
+		"description":       &hcldec.AttrSpec{Name: "description", Type: cty.String, Required: false},
 	}
 	return s
 }
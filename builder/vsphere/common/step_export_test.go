@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExportProductConfig_Prepare(t *testing.T) {
+	tc := []struct {
+		name           string
+		config         *ExportProductConfig
+		fail           bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Validate empty config",
+			config: &ExportProductConfig{},
+			fail:   false,
+		},
+		{
+			name: "Validate properties",
+			config: &ExportProductConfig{
+				Product: "Example Appliance",
+				Properties: []ExportPropertyConfig{
+					{Key: "hostname"},
+					{Key: "debug", Type: "boolean"},
+				},
+			},
+			fail: false,
+		},
+		{
+			name: "Missing property key",
+			config: &ExportProductConfig{
+				Properties: []ExportPropertyConfig{{Value: "appliance"}},
+			},
+			fail:           true,
+			expectedErrMsg: "'key' is required for 'property[0]'",
+		},
+		{
+			name: "Duplicate property key",
+			config: &ExportProductConfig{
+				Properties: []ExportPropertyConfig{{Key: "hostname"}, {Key: "hostname"}},
+			},
+			fail:           true,
+			expectedErrMsg: "duplicate property key: hostname",
+		},
+		{
+			name: "Unsupported property type",
+			config: &ExportProductConfig{
+				Properties: []ExportPropertyConfig{{Key: "hostname", Type: "text"}},
+			},
+			fail:           true,
+			expectedErrMsg: "unsupported type for property hostname: text",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			errs := c.config.Prepare()
+			if c.fail {
+				if len(errs) == 0 {
+					t.Fatalf("Config prepare should fail")
+				}
+				if errs[0].Error() != c.expectedErrMsg {
+					t.Fatalf("Expected error message: %s but was '%s'", c.expectedErrMsg, errs[0].Error())
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Fatalf("Config prepare should not fail: %s", errs[0])
+			}
+			for _, p := range c.config.Properties {
+				if p.Type == "" {
+					t.Fatalf("expected property type to be defaulted")
+				}
+			}
+		})
+	}
+}
+
+func TestAddProductSection(t *testing.T) {
+	descriptor := `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <VirtualSystem ovf:id="example">
+    <Info>A virtual machine</Info>
+  </VirtualSystem>
+</Envelope>`
+
+	product := &ExportProductConfig{
+		Product: "Example Appliance",
+		Vendor:  "Example & Co.",
+		Version: "1.0",
+		Properties: []ExportPropertyConfig{
+			{
+				Key:              "hostname",
+				Type:             "string",
+				Qualifiers:       "MinLen(1)",
+				Value:            "appliance",
+				UserConfigurable: true,
+				Label:            "Hostname",
+			},
+			{
+				Key:      "password",
+				Type:     "string",
+				Password: true,
+			},
+		},
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <VirtualSystem ovf:id="example">
+    <Info>A virtual machine</Info>
+    <ProductSection>
+      <Info>Information about the installed software</Info>
+      <Product>Example Appliance</Product>
+      <Vendor>Example &amp; Co.</Vendor>
+      <Version>1.0</Version>
+      <Property ovf:key="hostname" ovf:type="string" ovf:qualifiers="MinLen(1)" ovf:userConfigurable="true" ovf:value="appliance">
+        <Label>Hostname</Label>
+      </Property>
+      <Property ovf:key="password" ovf:type="string" ovf:userConfigurable="false" ovf:value="" ovf:password="true"></Property>
+    </ProductSection>
+  </VirtualSystem>
+</Envelope>`
+
+	actual, err := addProductSection(descriptor, product)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Fatalf("unexpected descriptor: %s", diff)
+	}
+
+	if _, err := addProductSection("<Envelope></Envelope>", product); err == nil {
+		t.Fatalf("expected to fail without a virtual system")
+	}
+}
+
+func TestAddProductSection_ExistingSection(t *testing.T) {
+	descriptor := `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <VirtualSystem ovf:id="example">
+    <Info>A virtual machine</Info>
+    <ProductSection ovf:class="com.vmware.guestInfo">
+      <Info>Guest information</Info>
+    </ProductSection>
+    <ProductSection>
+      <Info>Information about the installed software</Info>
+      <Property ovf:key="ip" ovf:type="string" ovf:userConfigurable="true" ovf:value=""></Property>
+    </ProductSection>
+  </VirtualSystem>
+</Envelope>`
+
+	product := &ExportProductConfig{
+		Product: "Example Appliance",
+		Version: "1.0",
+		Properties: []ExportPropertyConfig{
+			{
+				Key:   "hostname",
+				Type:  "string",
+				Value: "appliance",
+				Label: "Hostname",
+			},
+		},
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <VirtualSystem ovf:id="example">
+    <Info>A virtual machine</Info>
+    <ProductSection ovf:class="com.vmware.guestInfo">
+      <Info>Guest information</Info>
+    </ProductSection>
+    <ProductSection>
+      <Info>Information about the installed software</Info>
+      <Product>Example Appliance</Product>
+      <Version>1.0</Version>
+      <Property ovf:key="ip" ovf:type="string" ovf:userConfigurable="true" ovf:value=""></Property>
+      <Property ovf:key="hostname" ovf:type="string" ovf:userConfigurable="false" ovf:value="appliance">
+        <Label>Hostname</Label>
+      </Property>
+    </ProductSection>
+  </VirtualSystem>
+</Envelope>`
+
+	actual, err := addProductSection(descriptor, product)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Fatalf("unexpected descriptor: %s", diff)
+	}
+	if strings.Count(actual, "<ProductSection>") != 1 {
+		t.Fatalf("expected a single product section without a class")
+	}
+
+	_, err = addProductSection(descriptor, &ExportProductConfig{
+		Properties: []ExportPropertyConfig{{Key: "ip", Type: "string"}},
+	})
+	if err == nil || err.Error() != "the product section of the ovf descriptor already contains the property ip" {
+		t.Fatalf("expected to fail for a duplicate property, got %v", err)
+	}
+
+	_, err = addProductSection(actual, &ExportProductConfig{Product: "Other"})
+	if err == nil || err.Error() != "the product section of the ovf descriptor already contains Product information" {
+		t.Fatalf("expected to fail for duplicate product information, got %v", err)
+	}
+}
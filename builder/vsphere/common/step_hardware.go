@@ -52,6 +52,18 @@ type HardwareConfig struct {
 	ForceBIOSSetup bool `mapstructure:"force_bios_setup"`
 	// Add virtual TPM device for virtual machine. Defaults to `false`.
 	VTPMEnabled bool `mapstructure:"vTPM"`
+	// Set the placement of the virtual machine swap file. Supported values:
+	// `inherit` to use the setting of the host or cluster, `vmDirectory` to
+	// store the swap file in the virtual machine directory, or `hostLocal` to
+	// store the swap file in the datastore configured on the host. Defaults to
+	// the value of the virtual machine configuration, which is `inherit` for
+	// new virtual machines.
+	SwapPlacement string `mapstructure:"swap_placement"`
+	// Set the encrypted vMotion policy for virtual machine. Supported values:
+	// `disabled`, `opportunistic` or `required`. Defaults to the value of the
+	// virtual machine configuration, which is `opportunistic` for new virtual
+	// machines.
+	MigrateEncryption string `mapstructure:"migrate_encryption"`
 }
 
 func (c *HardwareConfig) Prepare() []error {
@@ -69,6 +81,14 @@ func (c *HardwareConfig) Prepare() []error {
 		errs = append(errs, fmt.Errorf("'vTPM' could be enabled only when 'firmware' set to 'efi' or 'efi-secure'"))
 	}
 
+	if c.SwapPlacement != "" && c.SwapPlacement != "inherit" && c.SwapPlacement != "vmDirectory" && c.SwapPlacement != "hostLocal" {
+		errs = append(errs, fmt.Errorf("'swap_placement' must be '', 'inherit', 'vmDirectory' or 'hostLocal'"))
+	}
+
+	if c.MigrateEncryption != "" && c.MigrateEncryption != "disabled" && c.MigrateEncryption != "opportunistic" && c.MigrateEncryption != "required" {
+		errs = append(errs, fmt.Errorf("'migrate_encryption' must be '', 'disabled', 'opportunistic' or 'required'"))
+	}
+
 	return errs
 }
 
@@ -100,6 +120,8 @@ func (s *StepConfigureHardware) Run(_ context.Context, state multistep.StateBag)
 			Firmware:            s.Config.Firmware,
 			ForceBIOSSetup:      s.Config.ForceBIOSSetup,
 			VTPMEnabled:         s.Config.VTPMEnabled,
+			SwapPlacement:       s.Config.SwapPlacement,
+			MigrateEncryption:   s.Config.MigrateEncryption,
 		})
 		if err != nil {
 			state.Put("error", err)
@@ -89,6 +89,29 @@ func TestHardwareConfig_Prepare(t *testing.T) {
 			fail:           true,
 			expectedErrMsg: "'vTPM' could be enabled only when 'firmware' set to 'efi' or 'efi-secure'",
 		},
+		{
+			name: "Validate 'vmDirectory' swap placement",
+			config: &HardwareConfig{
+				SwapPlacement: "vmDirectory",
+			},
+			fail: false,
+		},
+		{
+			name: "Invalid swap placement",
+			config: &HardwareConfig{
+				SwapPlacement: "datastore",
+			},
+			fail:           true,
+			expectedErrMsg: "'swap_placement' must be '', 'inherit', 'vmDirectory' or 'hostLocal'",
+		},
+		{
+			name: "Invalid migrate encryption",
+			config: &HardwareConfig{
+				MigrateEncryption: "always",
+			},
+			fail:           true,
+			expectedErrMsg: "'migrate_encryption' must be '', 'disabled', 'opportunistic' or 'required'",
+		},
 		{
 			name: "Validate 'vTPM' and empty firmware",
 			config: &HardwareConfig{
@@ -185,14 +208,16 @@ func TestStepConfigureHardware_Run(t *testing.T) {
 func basicStepConfigureHardware() *StepConfigureHardware {
 	return &StepConfigureHardware{
 		Config: &HardwareConfig{
-			CPUs:           1,
-			CpuCores:       1,
-			CPUReservation: 1,
-			CPULimit:       4000,
-			RAM:            1024,
-			RAMReserveAll:  true,
-			Firmware:       "efi-secure",
-			ForceBIOSSetup: true,
+			CPUs:              1,
+			CpuCores:          1,
+			CPUReservation:    1,
+			CPULimit:          4000,
+			RAM:               1024,
+			RAMReserveAll:     true,
+			Firmware:          "efi-secure",
+			ForceBIOSSetup:    true,
+			SwapPlacement:     "hostLocal",
+			MigrateEncryption: "required",
 		},
 	}
 }
@@ -213,5 +238,7 @@ func driverHardwareConfigFromConfig(config *HardwareConfig) *driver.HardwareConf
 		VGPUProfile:         config.VGPUProfile,
 		Firmware:            config.Firmware,
 		ForceBIOSSetup:      config.ForceBIOSSetup,
+		SwapPlacement:       config.SwapPlacement,
+		MigrateEncryption:   config.MigrateEncryption,
 	}
 }
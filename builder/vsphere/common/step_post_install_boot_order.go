@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+)
+
+// StepPostInstallBootOrder changes the boot order of the VM after the boot
+// command has been typed, so the next guest reboot uses the new order.
+type StepPostInstallBootOrder struct {
+	Config *RunConfig
+}
+
+func (s *StepPostInstallBootOrder) Run(_ context.Context, state multistep.StateBag) multistep.StepAction {
+	if s.Config.PostInstallBootOrder == "" {
+		return multistep.ActionContinue
+	}
+
+	ui := state.Get("ui").(packersdk.Ui)
+	vm := state.Get("vm").(driver.VirtualMachine)
+
+	ui.Say(fmt.Sprintf("Set post-install boot order to %s...", s.Config.PostInstallBootOrder))
+	if err := vm.SetBootOrder(parseBootOrder(s.Config.PostInstallBootOrder)); err != nil {
+		state.Put("error", fmt.Errorf("error setting post-install boot order: %s", err))
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepPostInstallBootOrder) Cleanup(multistep.StateBag) {}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+)
+
+func TestStepPostInstallBootOrder_Run(t *testing.T) {
+	tc := []struct {
+		name           string
+		step           *StepPostInstallBootOrder
+		vmMock         *driver.VirtualMachineMock
+		expectedAction multistep.StepAction
+		expectedVmMock *driver.VirtualMachineMock
+		errMessage     string
+	}{
+		{
+			name: "Skip when no post-install boot order is set",
+			step: &StepPostInstallBootOrder{
+				Config: &RunConfig{BootOrder: "cdrom,disk"},
+			},
+			vmMock:         new(driver.VirtualMachineMock),
+			expectedAction: multistep.ActionContinue,
+			expectedVmMock: new(driver.VirtualMachineMock),
+		},
+		{
+			name: "Set post-install boot order",
+			step: &StepPostInstallBootOrder{
+				Config: &RunConfig{
+					BootOrder:            "cdrom,disk",
+					PostInstallBootOrder: "disk,network",
+				},
+			},
+			vmMock:         new(driver.VirtualMachineMock),
+			expectedAction: multistep.ActionContinue,
+			expectedVmMock: &driver.VirtualMachineMock{
+				SetBootOrderCalled: true,
+				SetBootOrderOrder:  []string{"disk", "ethernet"},
+			},
+		},
+		{
+			name: "Fail to set post-install boot order",
+			step: &StepPostInstallBootOrder{
+				Config: &RunConfig{PostInstallBootOrder: "disk"},
+			},
+			vmMock: &driver.VirtualMachineMock{
+				SetBootOrderErr: fmt.Errorf("SetBootOrder error"),
+			},
+			expectedAction: multistep.ActionHalt,
+			expectedVmMock: &driver.VirtualMachineMock{
+				SetBootOrderCalled: true,
+				SetBootOrderOrder:  []string{"disk"},
+			},
+			errMessage: "error setting post-install boot order: SetBootOrder error",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			state := basicStateBag(nil)
+			state.Put("vm", c.vmMock)
+			if action := c.step.Run(context.TODO(), state); action != c.expectedAction {
+				t.Fatalf("unexpected action %v", action)
+			}
+			err, ok := state.Get("error").(error)
+			if ok {
+				if err.Error() != c.errMessage {
+					t.Fatalf("unexpected error %s", err.Error())
+				}
+			} else if c.errMessage != "" {
+				t.Fatalf("expected to fail but it didn't")
+			}
+
+			if diff := cmp.Diff(c.vmMock, c.expectedVmMock,
+				cmpopts.IgnoreInterfaces(struct{ error }{})); diff != "" {
+				t.Fatalf("unexpected VirtualMachine calls: %s", diff)
+			}
+		})
+	}
+}
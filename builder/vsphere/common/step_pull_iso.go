@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc struct-markdown
+//go:generate packer-sdc mapstructure-to-hcl2 -type RemoteISOConfig
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+	"github.com/vmware/govmomi/vapi/library"
+)
+
+type RemoteISOConfig struct {
+	// The name of a content library to which vCenter Server downloads the ISO
+	// from [iso_url](#iso_url) or [iso_urls](#iso_urls). The ISO is not
+	// downloaded by Packer nor uploaded to the datastore, which avoids
+	// transferring it twice and storing it on the machine running Packer. The
+	// URL must use HTTP or HTTPS and be reachable from vCenter Server.
+	//
+	// The ISO is stored in a library item named after the file name without
+	// its extension. The library item is kept after the build and reused by
+	// later builds only when vCenter Server reports a checksum of the same
+	// type and value as the [iso_checksum](#iso_checksum). Otherwise, the ISO
+	// is downloaded again. The content library must be of type Local. Not
+	// supported when connected to a standalone ESXi host.
+	//
+	// As the ISO is not downloaded by Packer, only vCenter Server verifies it.
+	// The [iso_checksum](#iso_checksum) must therefore be of type `md5`,
+	// `sha1`, `sha256` or `sha512` in the `type:value` format.
+	//
+	// The build fails when vCenter Server does not trust the certificate of an
+	// HTTPS URL, unless `insecure_connection` is `true`, in which case the
+	// certificate of the ISO server is accepted as well as that of vCenter
+	// Server.
+	ISOContentLibrary string `mapstructure:"iso_content_library"`
+}
+
+func (c *RemoteISOConfig) Prepare(isoUrls []string, isoChecksum string) []error {
+	var errs []error
+
+	if c.ISOContentLibrary == "" {
+		return errs
+	}
+
+	// Packer does not download the ISO, so vCenter Server is the only one
+	// able to verify it.
+	if libraryChecksum(isoChecksum) == nil {
+		errs = append(errs, fmt.Errorf("'iso_content_library' requires an 'iso_checksum' of type md5, sha1, sha256 or sha512 in the 'type:value' format"))
+	}
+
+	for _, source := range isoUrls {
+		if isHTTPURL(source) {
+			return errs
+		}
+	}
+	errs = append(errs, fmt.Errorf("'iso_content_library' requires an HTTP or HTTPS 'iso_url' or 'iso_urls'"))
+	return errs
+}
+
+type StepPullISO struct {
+	Config             *RemoteISOConfig
+	Url                []string
+	Checksum           string
+	InsecureConnection bool
+	HeartbeatInterval  time.Duration
+}
+
+func (s *StepPullISO) Run(_ context.Context, state multistep.StateBag) multistep.StepAction {
+	ui := state.Get("ui").(packersdk.Ui)
+	d := state.Get("driver").(driver.Driver)
+
+	checksum := libraryChecksum(s.Checksum)
+
+	var errs []string
+	for _, source := range s.Url {
+		if !isHTTPURL(source) {
+			log.Printf("Skipping %s, vCenter Server can only download HTTP or HTTPS URLs", source)
+			continue
+		}
+
+		libraryPath, downloaded, err := s.pullISO(ui, d, source, checksum)
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error downloading %s: %s", source, err))
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		if !downloaded {
+			ui.Say(fmt.Sprintf("File %s already in content library; continuing", libraryPath))
+		}
+		state.Put("iso_remote_path", libraryPath)
+		state.Put("SourceImageURL", source)
+		return multistep.ActionContinue
+	}
+
+	state.Put("error", fmt.Errorf("error downloading ISO to content library %s: %s",
+		s.Config.ISOContentLibrary, strings.Join(errs, "; ")))
+	return multistep.ActionHalt
+}
+
+func (s *StepPullISO) Cleanup(state multistep.StateBag) {}
+
+func (s *StepPullISO) pullISO(ui packersdk.Ui, d driver.Driver, source string, checksum *library.Checksum) (string, bool, error) {
+	thumbprint, err := d.ProbeContentLibrarySource(source)
+	if err != nil {
+		return "", false, err
+	}
+	if thumbprint != "" {
+		if !s.InsecureConnection {
+			return "", false, fmt.Errorf("vCenter Server does not trust the certificate of %s with the thumbprint %s; "+
+				"set 'insecure_connection' to accept it", source, thumbprint)
+		}
+		ui.Say(fmt.Sprintf("Accepting the untrusted certificate of %s with the thumbprint %s", source, thumbprint))
+	}
+
+	u, _ := url.Parse(source)
+	fileName := path.Base(u.Path)
+	itemName := strings.TrimSuffix(fileName, path.Ext(fileName))
+
+	ui.Say(fmt.Sprintf("Downloading %s to content library %s...", source, s.Config.ISOContentLibrary))
+	stop := StartHeartbeat(ui, s.HeartbeatInterval, "waiting for vCenter Server to download the ISO")
+	defer stop()
+	return d.PullContentLibraryFile(s.Config.ISOContentLibrary, itemName, fileName, source, thumbprint, checksum)
+}
+
+func isHTTPURL(source string) bool {
+	u, err := url.Parse(source)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// Converts a checksum in the `type:value` format to a checksum verified by
+// vCenter Server. Returns nil for checksum types not supported by vCenter
+// Server.
+func libraryChecksum(checksum string) *library.Checksum {
+	checksumType, value, found := strings.Cut(checksum, ":")
+	if !found || value == "" {
+		return nil
+	}
+
+	switch strings.ToLower(checksumType) {
+	case "md5", "sha1", "sha256", "sha512":
+		return &library.Checksum{
+			Algorithm: strings.ToUpper(checksumType),
+			Checksum:  strings.ToLower(value),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// Code generated by "packer-sdc mapstructure-to-hcl2"; DO NOT EDIT.
+
+package common
+
+import (
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FlatRemoteISOConfig is an auto-generated flat version of RemoteISOConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatRemoteISOConfig struct {
+	ISOContentLibrary *string `mapstructure:"iso_content_library" cty:"iso_content_library" hcl:"iso_content_library"`
+}
+
+// FlatMapstructure returns a new FlatRemoteISOConfig.
+// FlatRemoteISOConfig is an auto-generated flat version of RemoteISOConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*RemoteISOConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatRemoteISOConfig)
+}
+
+// HCL2Spec returns the hcl spec of a RemoteISOConfig.
+// This spec is used by HCL to read the fields of RemoteISOConfig.
+// The decoded values from this spec will then be applied to a FlatRemoteISOConfig.
+func (*FlatRemoteISOConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"iso_content_library": &hcldec.AttrSpec{Name: "iso_content_library", Type: cty.String, Required: false},
+	}
+	return s
+}
@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+	"github.com/vmware/govmomi/vapi/library"
+)
+
+func TestRemoteISOConfig_Prepare(t *testing.T) {
+	tc := []struct {
+		name           string
+		config         *RemoteISOConfig
+		isoUrls        []string
+		isoChecksum    string
+		fail           bool
+		expectedErrMsg string
+	}{
+		{
+			name:    "Should not fail for empty config",
+			config:  new(RemoteISOConfig),
+			isoUrls: []string{"./local.iso"},
+			fail:    false,
+		},
+		{
+			name: "Content library with HTTP URL",
+			config: &RemoteISOConfig{
+				ISOContentLibrary: "Packer",
+			},
+			isoUrls:     []string{"./local.iso", "https://example.com/ubuntu.iso"},
+			isoChecksum: "sha256:abcdef",
+			fail:        false,
+		},
+		{
+			name: "Content library without HTTP URL",
+			config: &RemoteISOConfig{
+				ISOContentLibrary: "Packer",
+			},
+			isoUrls:        []string{"./local.iso"},
+			isoChecksum:    "sha256:abcdef",
+			fail:           true,
+			expectedErrMsg: "'iso_content_library' requires an HTTP or HTTPS 'iso_url' or 'iso_urls'",
+		},
+		{
+			name: "Content library with checksum file",
+			config: &RemoteISOConfig{
+				ISOContentLibrary: "Packer",
+			},
+			isoUrls:        []string{"https://example.com/ubuntu.iso"},
+			isoChecksum:    "file:https://example.com/SHA256SUMS",
+			fail:           true,
+			expectedErrMsg: "'iso_content_library' requires an 'iso_checksum' of type md5, sha1, sha256 or sha512 in the 'type:value' format",
+		},
+		{
+			name: "Content library with bare checksum",
+			config: &RemoteISOConfig{
+				ISOContentLibrary: "Packer",
+			},
+			isoUrls:        []string{"https://example.com/ubuntu.iso"},
+			isoChecksum:    "abcdef",
+			fail:           true,
+			expectedErrMsg: "'iso_content_library' requires an 'iso_checksum' of type md5, sha1, sha256 or sha512 in the 'type:value' format",
+		},
+		{
+			name: "Content library without checksum",
+			config: &RemoteISOConfig{
+				ISOContentLibrary: "Packer",
+			},
+			isoUrls:        []string{"https://example.com/ubuntu.iso"},
+			isoChecksum:    "none",
+			fail:           true,
+			expectedErrMsg: "'iso_content_library' requires an 'iso_checksum' of type md5, sha1, sha256 or sha512 in the 'type:value' format",
+		},
+	}
+
+	for _, c := range tc {
+		errs := c.config.Prepare(c.isoUrls, c.isoChecksum)
+		if c.fail {
+			if len(errs) == 0 {
+				t.Fatalf("Config prepare should fail")
+			}
+			if errs[0].Error() != c.expectedErrMsg {
+				t.Fatalf("Expected error message: %s but was '%s'", c.expectedErrMsg, errs[0].Error())
+			}
+		} else {
+			if len(errs) != 0 {
+				t.Fatalf("Config prepare should not fail")
+			}
+		}
+	}
+}
+
+func TestStepPullISO_Run(t *testing.T) {
+	tc := []struct {
+		name               string
+		step               *StepPullISO
+		driverMock         *driver.DriverMock
+		expectedAction     multistep.StepAction
+		expectedDriverMock *driver.DriverMock
+		expectedRemotePath string
+		errMessage         string
+	}{
+		{
+			name: "Download ISO to content library",
+			step: &StepPullISO{
+				Config: &RemoteISOConfig{
+					ISOContentLibrary: "Packer",
+				},
+				Url:      []string{"./local.iso", "https://example.com/isos/ubuntu-server.iso"},
+				Checksum: "sha256:ABCDEF",
+			},
+			driverMock: &driver.DriverMock{
+				PullContentLibraryFileResponse:   "Packer/ubuntu-server/ubuntu-server.iso",
+				PullContentLibraryFileDownloaded: true,
+			},
+			expectedAction: multistep.ActionContinue,
+			expectedDriverMock: &driver.DriverMock{
+				ProbeContentLibrarySourceCalled: true,
+				ProbeContentLibrarySourceURI:    "https://example.com/isos/ubuntu-server.iso",
+				PullContentLibraryFileCalled:    true,
+				PullContentLibraryFileLibrary:   "Packer",
+				PullContentLibraryFileItem:      "ubuntu-server",
+				PullContentLibraryFileName:      "ubuntu-server.iso",
+				PullContentLibraryFileURI:       "https://example.com/isos/ubuntu-server.iso",
+				PullContentLibraryFileChecksum: &library.Checksum{
+					Algorithm: "SHA256",
+					Checksum:  "abcdef",
+				},
+				PullContentLibraryFileResponse:   "Packer/ubuntu-server/ubuntu-server.iso",
+				PullContentLibraryFileDownloaded: true,
+			},
+			expectedRemotePath: "Packer/ubuntu-server/ubuntu-server.iso",
+		},
+		{
+			name: "Fail to download ISO to content library",
+			step: &StepPullISO{
+				Config: &RemoteISOConfig{
+					ISOContentLibrary: "Packer",
+				},
+				Url:      []string{"http://example.com/ubuntu-server.iso"},
+				Checksum: "none",
+			},
+			driverMock: &driver.DriverMock{
+				PullContentLibraryFileErr: fmt.Errorf("pull error"),
+			},
+			expectedAction: multistep.ActionHalt,
+			expectedDriverMock: &driver.DriverMock{
+				ProbeContentLibrarySourceCalled: true,
+				ProbeContentLibrarySourceURI:    "http://example.com/ubuntu-server.iso",
+				PullContentLibraryFileCalled:    true,
+				PullContentLibraryFileLibrary:   "Packer",
+				PullContentLibraryFileItem:      "ubuntu-server",
+				PullContentLibraryFileName:      "ubuntu-server.iso",
+				PullContentLibraryFileURI:       "http://example.com/ubuntu-server.iso",
+			},
+			errMessage: "error downloading ISO to content library Packer: pull error",
+		},
+		{
+			name: "Accept untrusted certificate",
+			step: &StepPullISO{
+				Config: &RemoteISOConfig{
+					ISOContentLibrary: "Packer",
+				},
+				Url:                []string{"https://example.com/ubuntu-server.iso"},
+				Checksum:           "none",
+				InsecureConnection: true,
+			},
+			driverMock: &driver.DriverMock{
+				ProbeContentLibrarySourceResponse: "AB:CD:EF",
+				PullContentLibraryFileResponse:    "Packer/ubuntu-server/ubuntu-server.iso",
+				PullContentLibraryFileDownloaded:  true,
+			},
+			expectedAction: multistep.ActionContinue,
+			expectedDriverMock: &driver.DriverMock{
+				ProbeContentLibrarySourceCalled:   true,
+				ProbeContentLibrarySourceURI:      "https://example.com/ubuntu-server.iso",
+				ProbeContentLibrarySourceResponse: "AB:CD:EF",
+				PullContentLibraryFileCalled:      true,
+				PullContentLibraryFileLibrary:     "Packer",
+				PullContentLibraryFileItem:        "ubuntu-server",
+				PullContentLibraryFileName:        "ubuntu-server.iso",
+				PullContentLibraryFileURI:         "https://example.com/ubuntu-server.iso",
+				PullContentLibraryFileThumbprint:  "AB:CD:EF",
+				PullContentLibraryFileResponse:    "Packer/ubuntu-server/ubuntu-server.iso",
+				PullContentLibraryFileDownloaded:  true,
+			},
+			expectedRemotePath: "Packer/ubuntu-server/ubuntu-server.iso",
+		},
+		{
+			name: "Fail on untrusted certificate",
+			step: &StepPullISO{
+				Config: &RemoteISOConfig{
+					ISOContentLibrary: "Packer",
+				},
+				Url:      []string{"https://example.com/ubuntu-server.iso"},
+				Checksum: "none",
+			},
+			driverMock: &driver.DriverMock{
+				ProbeContentLibrarySourceResponse: "AB:CD:EF",
+			},
+			expectedAction: multistep.ActionHalt,
+			expectedDriverMock: &driver.DriverMock{
+				ProbeContentLibrarySourceCalled:   true,
+				ProbeContentLibrarySourceURI:      "https://example.com/ubuntu-server.iso",
+				ProbeContentLibrarySourceResponse: "AB:CD:EF",
+			},
+			errMessage: "error downloading ISO to content library Packer: vCenter Server does not trust the certificate of " +
+				"https://example.com/ubuntu-server.iso with the thumbprint AB:CD:EF; set 'insecure_connection' to accept it",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			state := basicStateBag(new(strings.Builder))
+			state.Put("driver", c.driverMock)
+			if action := c.step.Run(context.TODO(), state); action != c.expectedAction {
+				t.Fatalf("unexpected action %v", action)
+			}
+			err, ok := state.Get("error").(error)
+			if ok {
+				if err.Error() != c.errMessage {
+					t.Fatalf("unexpected error %s", err.Error())
+				}
+			} else if c.errMessage != "" {
+				t.Fatalf("expected to fail but it didn't")
+			}
+
+			remotePath, _ := state.Get("iso_remote_path").(string)
+			if remotePath != c.expectedRemotePath {
+				t.Fatalf("unexpected iso_remote_path %q", remotePath)
+			}
+
+			if diff := cmp.Diff(c.driverMock, c.expectedDriverMock,
+				cmpopts.IgnoreInterfaces(struct{ error }{})); diff != "" {
+				t.Fatalf("unexpected Driver calls: %s", diff)
+			}
+		})
+	}
+}
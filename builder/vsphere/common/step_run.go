@@ -8,6 +8,7 @@ package common
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
@@ -16,8 +17,71 @@ import (
 )
 
 type RunConfig struct {
-	// Priority of boot devices. Defaults to `disk,cdrom`
+	// Priority of boot devices. Defaults to `disk,cdrom`.
+	// Accepts a comma-separated list of device types (`disk`, `cdrom`,
+	// `ethernet` or its alias `network`, and `floppy`) or device names, such
+	// as `disk-1000-0` or `ethernet-0`, to boot from one specific device. The
+	// build fails if a device name does not match a device of the VM.
+	// Specific EFI boot entries are not supported, as vSphere only orders the
+	// devices of the VM.
 	BootOrder string `mapstructure:"boot_order"` // example: "floppy,cdrom,ethernet,disk"
+	// Priority of boot devices to set once the `boot_command` has been typed,
+	// using the same format as `boot_order`. The new order takes effect on the
+	// next boot of the guest, such as the reboot at the end of the operating
+	// system installation, which prevents the VM from booting back into the
+	// installer. The order is kept on the resulting VM or template.
+	// Example: `disk,cdrom`.
+	PostInstallBootOrder string `mapstructure:"post_install_boot_order"`
+}
+
+var bootDeviceTypes = []string{"disk", "cdrom", "ethernet", "network", "floppy", "-"}
+
+func (c *RunConfig) Prepare() []error {
+	var errs []error
+
+	if err := validateBootOrder(c.BootOrder); err != nil {
+		errs = append(errs, fmt.Errorf("'boot_order' %s", err))
+	}
+	if err := validateBootOrder(c.PostInstallBootOrder); err != nil {
+		errs = append(errs, fmt.Errorf("'post_install_boot_order' %s", err))
+	}
+
+	return errs
+}
+
+func validateBootOrder(order string) error {
+	if order == "" {
+		return nil
+	}
+
+	for _, device := range parseBootOrder(order) {
+		valid := false
+		for _, t := range bootDeviceTypes {
+			if device == t || strings.HasPrefix(device, t+"-") {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("contains unsupported boot device %q", device)
+		}
+	}
+
+	return nil
+}
+
+// parseBootOrder splits a comma-separated boot order into the device names
+// understood by the driver.
+func parseBootOrder(order string) []string {
+	var devices []string
+	for _, device := range strings.Split(order, ",") {
+		device = strings.TrimSpace(device)
+		if device == "network" {
+			device = "ethernet"
+		}
+		devices = append(devices, device)
+	}
+	return devices
 }
 
 type StepRun struct {
@@ -31,7 +95,7 @@ func (s *StepRun) Run(_ context.Context, state multistep.StateBag) multistep.Ste
 
 	if s.Config.BootOrder != "" {
 		ui.Say("Set boot order...")
-		order := strings.Split(s.Config.BootOrder, ",")
+		order := parseBootOrder(s.Config.BootOrder)
 		if err := vm.SetBootOrder(order); err != nil {
 			state.Put("error", err)
 			return multistep.ActionHalt
@@ -60,7 +124,7 @@ func (s *StepRun) Cleanup(state multistep.StateBag) {
 	ui := state.Get("ui").(packersdk.Ui)
 	vm := state.Get("vm").(*driver.VirtualMachineDriver)
 
-	if s.Config.BootOrder == "" && s.SetOrder {
+	if s.Config.BootOrder == "" && s.Config.PostInstallBootOrder == "" && s.SetOrder {
 		ui.Say("Clear boot order...")
 		if err := vm.SetBootOrder([]string{"-"}); err != nil {
 			state.Put("error", err)
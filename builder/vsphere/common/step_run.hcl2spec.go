@@ -10,7 +10,8 @@ import (
 // FlatRunConfig is an auto-generated flat version of RunConfig.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatRunConfig struct {
-	BootOrder *string `mapstructure:"boot_order" cty:"boot_order" hcl:"boot_order"`
+	BootOrder            *string `mapstructure:"boot_order" cty:"boot_order" hcl:"boot_order"`
+	PostInstallBootOrder *string `mapstructure:"post_install_boot_order" cty:"post_install_boot_order" hcl:"post_install_boot_order"`
 }
 
 // FlatMapstructure returns a new FlatRunConfig.
@@ -25,7 +26,8 @@ func (*RunConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spe
 // The decoded values from this spec will then be applied to a FlatRunConfig.
 func (*FlatRunConfig) HCL2Spec() map[string]hcldec.Spec {
 	s := map[string]hcldec.Spec{
-		"boot_order": &hcldec.AttrSpec{Name: "boot_order", Type: cty.String, Required: false},
+		"boot_order":              &hcldec.AttrSpec{Name: "boot_order", Type: cty.String, Required: false},
+		"post_install_boot_order": &hcldec.AttrSpec{Name: "post_install_boot_order", Type: cty.String, Required: false},
 	}
 	return s
 }
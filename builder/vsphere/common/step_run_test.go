@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunConfig_Prepare(t *testing.T) {
+	tc := []struct {
+		name           string
+		config         *RunConfig
+		fail           bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Validate empty config",
+			config: &RunConfig{},
+			fail:   false,
+		},
+		{
+			name: "Validate device types",
+			config: &RunConfig{
+				BootOrder:            "floppy,cdrom,network,ethernet,disk",
+				PostInstallBootOrder: "disk,cdrom",
+			},
+			fail: false,
+		},
+		{
+			name: "Validate device names",
+			config: &RunConfig{
+				BootOrder: "disk-1000-0, ethernet-0, cdrom-3000",
+			},
+			fail: false,
+		},
+		{
+			name: "Invalid boot_order device",
+			config: &RunConfig{
+				BootOrder: "disk,usb",
+			},
+			fail:           true,
+			expectedErrMsg: "'boot_order' contains unsupported boot device \"usb\"",
+		},
+		{
+			name: "Invalid post_install_boot_order device",
+			config: &RunConfig{
+				PostInstallBootOrder: "disk,",
+			},
+			fail:           true,
+			expectedErrMsg: "'post_install_boot_order' contains unsupported boot device \"\"",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			errs := c.config.Prepare()
+			if c.fail {
+				if len(errs) == 0 {
+					t.Fatalf("Config prepare should fail")
+				}
+				if errs[0].Error() != c.expectedErrMsg {
+					t.Fatalf("Expected error message: %s but was '%s'", c.expectedErrMsg, errs[0].Error())
+				}
+			} else {
+				if len(errs) != 0 {
+					t.Fatalf("Config prepare should not fail: %s", errs[0])
+				}
+			}
+		})
+	}
+}
+
+func TestParseBootOrder(t *testing.T) {
+	expected := []string{"disk", "ethernet", "cdrom-3000"}
+	if diff := cmp.Diff(parseBootOrder("disk, network,cdrom-3000"), expected); diff != "" {
+		t.Fatalf("unexpected boot order: %s", diff)
+	}
+}
@@ -51,7 +51,8 @@ func (c *ShutdownConfig) Prepare(comm communicator.Config) (warnings []string, e
 }
 
 type StepShutdown struct {
-	Config *ShutdownConfig
+	Config            *ShutdownConfig
+	HeartbeatInterval time.Duration
 }
 
 func (s *StepShutdown) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
@@ -100,7 +101,9 @@ func (s *StepShutdown) Run(ctx context.Context, state multistep.StateBag) multis
 	}
 
 	log.Printf("Waiting max %s for shutdown to complete", s.Config.Timeout)
+	stop := StartHeartbeat(ui, s.HeartbeatInterval, "waiting for VM to shut down")
 	err := vm.WaitForShutdown(ctx, s.Config.Timeout)
+	stop()
 	if err != nil {
 		state.Put("error", err)
 		return multistep.ActionHalt
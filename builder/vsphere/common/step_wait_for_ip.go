@@ -44,7 +44,8 @@ type WaitIpConfig struct {
 }
 
 type StepWaitForIp struct {
-	Config *WaitIpConfig
+	Config            *WaitIpConfig
+	HeartbeatInterval time.Duration
 }
 
 func (c *WaitIpConfig) Prepare() []error {
@@ -96,6 +97,8 @@ func (s *StepWaitForIp) Run(ctx context.Context, state multistep.StateBag) multi
 	}()
 
 	log.Printf("[INFO] Waiting for IP, up to total timeout: %s, settle timeout: %s", s.Config.WaitTimeout, s.Config.SettleTimeout)
+	stop := StartHeartbeat(ui, s.HeartbeatInterval, "waiting for IP")
+	defer stop()
 	timeout := time.After(s.Config.WaitTimeout)
 	for {
 		select {
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:generate packer-sdc struct-markdown
+//go:generate packer-sdc mapstructure-to-hcl2 -type WindowsSysprepConfig
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/communicator"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+)
+
+const sysprepCommand = `C:\Windows\System32\Sysprep\sysprep.exe /generalize /oobe /shutdown /quiet`
+
+// With this configuration Packer generalizes the Windows guest operating system
+// with sysprep after all provisioners have run. Sysprep shuts down the
+// virtual machine, and Packer waits for the power-off before continuing with the
+// snapshot, template conversion, content library import or export.
+type WindowsSysprepConfig struct {
+	// The path to an answer file on the machine running Packer. The file is
+	// copied to the guest and passed to sysprep with the `/unattend` option.
+	// Optional.
+	UnattendFile string `mapstructure:"unattend_file"`
+	// The path in the guest to which the [unattend_file](#unattend_file) is
+	// copied. Defaults to `C:\Windows\Panther\unattend.xml`.
+	UnattendPath string `mapstructure:"unattend_path"`
+	// Amount of time to wait for sysprep to generalize and shut down the
+	// virtual machine. Defaults to `15m` or fifteen minutes.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+func (c *WindowsSysprepConfig) Prepare(comm communicator.Config) []error {
+	var errs []error
+
+	if comm.Type == "none" {
+		errs = append(errs, fmt.Errorf("'windows_sysprep' requires a communicator"))
+	}
+	if c.UnattendFile != "" {
+		if _, err := os.Stat(c.UnattendFile); err != nil {
+			errs = append(errs, fmt.Errorf("unable to read 'unattend_file': %s", err))
+		}
+	}
+
+	if c.UnattendPath == "" {
+		c.UnattendPath = `C:\Windows\Panther\unattend.xml`
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 15 * time.Minute
+	}
+	return errs
+}
+
+type StepWindowsSysprep struct {
+	Config            *WindowsSysprepConfig
+	HeartbeatInterval time.Duration
+}
+
+func (s *StepWindowsSysprep) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ui := state.Get("ui").(packersdk.Ui)
+	vm := state.Get("vm").(driver.VirtualMachine)
+	comm := state.Get("communicator").(packersdk.Communicator)
+
+	command := sysprepCommand
+	if s.Config.UnattendFile != "" {
+		ui.Say(fmt.Sprintf("Uploading answer file to %s...", s.Config.UnattendPath))
+		if err := uploadGuestFile(comm, s.Config.UnattendFile, s.Config.UnattendPath); err != nil {
+			state.Put("error", fmt.Errorf("error uploading answer file: %s", err))
+			return multistep.ActionHalt
+		}
+		command = fmt.Sprintf(`%s /unattend:"%s"`, command, s.Config.UnattendPath)
+	}
+
+	ui.Say("Running sysprep...")
+	log.Printf("Sysprep command: %s", command)
+
+	var stdout, stderr bytes.Buffer
+	cmd := &packersdk.RemoteCmd{
+		Command: command,
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	}
+	if err := comm.Start(ctx, cmd); err != nil {
+		state.Put("error", fmt.Errorf("error running sysprep: %s", err))
+		return multistep.ActionHalt
+	}
+
+	// The connection is usually lost while the guest shuts down, so only a
+	// failure reported by sysprep before the power-off stops the wait.
+	exited := make(chan int, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- vm.WaitForShutdown(waitCtx, s.Config.Timeout)
+	}()
+
+	log.Printf("Waiting max %s for sysprep to shut down the VM", s.Config.Timeout)
+	stop := StartHeartbeat(ui, s.HeartbeatInterval, "waiting for sysprep to shut down the VM")
+	defer stop()
+	for {
+		select {
+		case err := <-shutdown:
+			if err != nil {
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+			return multistep.ActionContinue
+		case status := <-exited:
+			if status == 0 || status == packersdk.CmdDisconnect {
+				exited = nil
+				continue
+			}
+			cancel()
+			<-shutdown
+
+			err := fmt.Errorf("sysprep exited with status %d", status)
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%s: %s", err, msg)
+			}
+			state.Put("error", err)
+			return multistep.ActionHalt
+		}
+	}
+}
+
+func (s *StepWindowsSysprep) Cleanup(state multistep.StateBag) {}
+
+func uploadGuestFile(comm packersdk.Communicator, src string, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return comm.Upload(dst, f, &fi)
+}
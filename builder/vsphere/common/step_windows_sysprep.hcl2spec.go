@@ -0,0 +1,35 @@
+// Code generated by "packer-sdc mapstructure-to-hcl2"; DO NOT EDIT.
+
+package common
+
+import (
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FlatWindowsSysprepConfig is an auto-generated flat version of WindowsSysprepConfig.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatWindowsSysprepConfig struct {
+	UnattendFile *string `mapstructure:"unattend_file" cty:"unattend_file" hcl:"unattend_file"`
+	UnattendPath *string `mapstructure:"unattend_path" cty:"unattend_path" hcl:"unattend_path"`
+	Timeout      *string `mapstructure:"timeout" cty:"timeout" hcl:"timeout"`
+}
+
+// FlatMapstructure returns a new FlatWindowsSysprepConfig.
+// FlatWindowsSysprepConfig is an auto-generated flat version of WindowsSysprepConfig.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*WindowsSysprepConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatWindowsSysprepConfig)
+}
+
+// HCL2Spec returns the hcl spec of a WindowsSysprepConfig.
+// This spec is used by HCL to read the fields of WindowsSysprepConfig.
+// The decoded values from this spec will then be applied to a FlatWindowsSysprepConfig.
+func (*FlatWindowsSysprepConfig) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"unattend_file": &hcldec.AttrSpec{Name: "unattend_file", Type: cty.String, Required: false},
+		"unattend_path": &hcldec.AttrSpec{Name: "unattend_path", Type: cty.String, Required: false},
+		"timeout":       &hcldec.AttrSpec{Name: "timeout", Type: cty.String, Required: false},
+	}
+	return s
+}
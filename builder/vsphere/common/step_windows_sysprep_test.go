@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/packer-plugin-sdk/communicator"
+	"github.com/hashicorp/packer-plugin-sdk/multistep"
+	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	"github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/driver"
+)
+
+func TestWindowsSysprepConfig_Prepare(t *testing.T) {
+	tc := []struct {
+		name           string
+		config         *WindowsSysprepConfig
+		comm           communicator.Config
+		fail           bool
+		expectedErrMsg string
+	}{
+		{
+			name:   "Should not fail for empty config",
+			config: new(WindowsSysprepConfig),
+			comm:   communicator.Config{Type: "winrm"},
+			fail:   false,
+		},
+		{
+			name:           "Communicator is none",
+			config:         new(WindowsSysprepConfig),
+			comm:           communicator.Config{Type: "none"},
+			fail:           true,
+			expectedErrMsg: "'windows_sysprep' requires a communicator",
+		},
+		{
+			name: "Missing unattend file",
+			config: &WindowsSysprepConfig{
+				UnattendFile: filepath.Join(t.TempDir(), "missing.xml"),
+			},
+			comm: communicator.Config{Type: "winrm"},
+			fail: true,
+		},
+	}
+
+	for _, c := range tc {
+		errs := c.config.Prepare(c.comm)
+		if c.fail {
+			if len(errs) == 0 {
+				t.Fatalf("Config prepare should fail")
+			}
+			if c.expectedErrMsg != "" && errs[0].Error() != c.expectedErrMsg {
+				t.Fatalf("Expected error message: %s but was '%s'", c.expectedErrMsg, errs[0].Error())
+			}
+		} else {
+			if len(errs) != 0 {
+				t.Fatalf("Config prepare should not fail")
+			}
+			if c.config.UnattendPath != `C:\Windows\Panther\unattend.xml` {
+				t.Fatalf("unexpected default unattend path %s", c.config.UnattendPath)
+			}
+			if c.config.Timeout != 15*time.Minute {
+				t.Fatalf("unexpected default timeout %s", c.config.Timeout)
+			}
+		}
+	}
+}
+
+func TestStepWindowsSysprep_Run(t *testing.T) {
+	unattendFile := filepath.Join(t.TempDir(), "unattend.xml")
+	if err := os.WriteFile(unattendFile, []byte("<unattend/>"), 0644); err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+
+	tc := []struct {
+		name            string
+		config          *WindowsSysprepConfig
+		vmMock          *driver.VirtualMachineMock
+		exitStatus      int
+		expectedAction  multistep.StepAction
+		expectedCommand string
+		expectedUpload  bool
+		errMessage      string
+	}{
+		{
+			name: "Run sysprep",
+			config: &WindowsSysprepConfig{
+				Timeout: time.Minute,
+			},
+			vmMock:          new(driver.VirtualMachineMock),
+			expectedAction:  multistep.ActionContinue,
+			expectedCommand: `C:\Windows\System32\Sysprep\sysprep.exe /generalize /oobe /shutdown /quiet`,
+		},
+		{
+			name: "Run sysprep with unattend file",
+			config: &WindowsSysprepConfig{
+				UnattendFile: unattendFile,
+				UnattendPath: `C:\Windows\Panther\unattend.xml`,
+				Timeout:      time.Minute,
+			},
+			vmMock:          new(driver.VirtualMachineMock),
+			expectedAction:  multistep.ActionContinue,
+			expectedCommand: `C:\Windows\System32\Sysprep\sysprep.exe /generalize /oobe /shutdown /quiet /unattend:"C:\Windows\Panther\unattend.xml"`,
+			expectedUpload:  true,
+		},
+		{
+			name: "Connection lost during shutdown",
+			config: &WindowsSysprepConfig{
+				Timeout: time.Minute,
+			},
+			vmMock:          new(driver.VirtualMachineMock),
+			exitStatus:      packersdk.CmdDisconnect,
+			expectedAction:  multistep.ActionContinue,
+			expectedCommand: `C:\Windows\System32\Sysprep\sysprep.exe /generalize /oobe /shutdown /quiet`,
+		},
+		{
+			name: "Sysprep fails",
+			config: &WindowsSysprepConfig{
+				Timeout: time.Hour,
+			},
+			vmMock: &driver.VirtualMachineMock{
+				WaitForShutdownPoweredOn: true,
+			},
+			exitStatus:      1,
+			expectedAction:  multistep.ActionHalt,
+			expectedCommand: `C:\Windows\System32\Sysprep\sysprep.exe /generalize /oobe /shutdown /quiet`,
+			errMessage:      "sysprep exited with status 1",
+		},
+		{
+			name: "Fail to shut down",
+			config: &WindowsSysprepConfig{
+				Timeout: time.Minute,
+			},
+			vmMock: &driver.VirtualMachineMock{
+				WaitForShutdownErr: fmt.Errorf("Timeout while waiting for machine to shut down."),
+			},
+			exitStatus:      packersdk.CmdDisconnect,
+			expectedAction:  multistep.ActionHalt,
+			expectedCommand: `C:\Windows\System32\Sysprep\sysprep.exe /generalize /oobe /shutdown /quiet`,
+			errMessage:      "Timeout while waiting for machine to shut down.",
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			comm := &packersdk.MockCommunicator{
+				StartExitStatus: c.exitStatus,
+			}
+			state := basicStateBag(nil)
+			state.Put("vm", c.vmMock)
+			state.Put("communicator", comm)
+
+			step := &StepWindowsSysprep{
+				Config: c.config,
+			}
+			if action := step.Run(context.TODO(), state); action != c.expectedAction {
+				t.Fatalf("unexpected action %v", action)
+			}
+			err, ok := state.Get("error").(error)
+			if ok {
+				if c.errMessage == "" || !strings.HasPrefix(err.Error(), c.errMessage) {
+					t.Fatalf("unexpected error %s", err.Error())
+				}
+			} else if c.errMessage != "" {
+				t.Fatalf("expected to fail but it didn't")
+			}
+
+			if comm.StartCmd.Command != c.expectedCommand {
+				t.Fatalf("unexpected command %s", comm.StartCmd.Command)
+			}
+			if comm.UploadCalled != c.expectedUpload {
+				t.Fatalf("unexpected upload %t", comm.UploadCalled)
+			}
+			if c.expectedUpload && (comm.UploadPath != c.config.UnattendPath || comm.UploadData != "<unattend/>") {
+				t.Fatalf("unexpected upload of %q to %s", comm.UploadData, comm.UploadPath)
+			}
+			if !c.vmMock.WaitForShutdownCalled || c.vmMock.WaitForShutdownTimeout != c.config.Timeout {
+				t.Fatalf("expected to wait %s for the shutdown", c.config.Timeout)
+			}
+		})
+	}
+}
@@ -99,6 +99,17 @@ type DiskConfig struct {
 	DiskEagerlyScrub bool `mapstructure:"disk_eagerly_scrub"`
 	// The assigned disk controller. Defaults to the first one (0).
 	DiskControllerIndex int `mapstructure:"disk_controller_index"`
+	// The name of the VM encryption storage policy used to encrypt the disk.
+	// Must be set together with `disk_key_provider`. The virtual machine home
+	// is encrypted with the policy and key provider of the first encrypted
+	// disk, as required by vSphere. With `vsphere-clone`, the disks of the
+	// template are encrypted with the same policy and key provider, and
+	// `linked_clone` is not supported.
+	DiskEncryptionPolicy string `mapstructure:"disk_encryption_policy"`
+	// The ID of the key provider used to encrypt the disk. The key is
+	// generated by the key provider at the time of encryption. Must be set
+	// together with `disk_encryption_policy`.
+	DiskKeyProvider string `mapstructure:"disk_key_provider"`
 }
 
 type StorageConfig struct {
@@ -122,6 +133,9 @@ func (c *StorageConfig) Prepare() []error {
 			if storage.DiskControllerIndex >= len(c.DiskControllerType) {
 				errs = append(errs, fmt.Errorf("storage[%d].'disk_controller_index' references an unknown disk controller", i))
 			}
+			if (storage.DiskEncryptionPolicy == "") != (storage.DiskKeyProvider == "") {
+				errs = append(errs, fmt.Errorf("storage[%d].'disk_encryption_policy' and storage[%d].'disk_key_provider' must be set together", i, i))
+			}
 		}
 	}
 
@@ -10,10 +10,12 @@ import (
 // FlatDiskConfig is an auto-generated flat version of DiskConfig.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatDiskConfig struct {
-	DiskSize            *int64 `mapstructure:"disk_size" required:"true" cty:"disk_size" hcl:"disk_size"`
-	DiskThinProvisioned *bool  `mapstructure:"disk_thin_provisioned" cty:"disk_thin_provisioned" hcl:"disk_thin_provisioned"`
-	DiskEagerlyScrub    *bool  `mapstructure:"disk_eagerly_scrub" cty:"disk_eagerly_scrub" hcl:"disk_eagerly_scrub"`
-	DiskControllerIndex *int   `mapstructure:"disk_controller_index" cty:"disk_controller_index" hcl:"disk_controller_index"`
+	DiskSize             *int64  `mapstructure:"disk_size" required:"true" cty:"disk_size" hcl:"disk_size"`
+	DiskThinProvisioned  *bool   `mapstructure:"disk_thin_provisioned" cty:"disk_thin_provisioned" hcl:"disk_thin_provisioned"`
+	DiskEagerlyScrub     *bool   `mapstructure:"disk_eagerly_scrub" cty:"disk_eagerly_scrub" hcl:"disk_eagerly_scrub"`
+	DiskControllerIndex  *int    `mapstructure:"disk_controller_index" cty:"disk_controller_index" hcl:"disk_controller_index"`
+	DiskEncryptionPolicy *string `mapstructure:"disk_encryption_policy" cty:"disk_encryption_policy" hcl:"disk_encryption_policy"`
+	DiskKeyProvider      *string `mapstructure:"disk_key_provider" cty:"disk_key_provider" hcl:"disk_key_provider"`
 }
 
 // FlatMapstructure returns a new FlatDiskConfig.
@@ -28,10 +30,12 @@ func (*DiskConfig) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Sp
 // The decoded values from this spec will then be applied to a FlatDiskConfig.
 func (*FlatDiskConfig) HCL2Spec() map[string]hcldec.Spec {
 	s := map[string]hcldec.Spec{
-		"disk_size":             &hcldec.AttrSpec{Name: "disk_size", Type: cty.Number, Required: false},
-		"disk_thin_provisioned": &hcldec.AttrSpec{Name: "disk_thin_provisioned", Type: cty.Bool, Required: false},
-		"disk_eagerly_scrub":    &hcldec.AttrSpec{Name: "disk_eagerly_scrub", Type: cty.Bool, Required: false},
-		"disk_controller_index": &hcldec.AttrSpec{Name: "disk_controller_index", Type: cty.Number, Required: false},
+		"disk_size":              &hcldec.AttrSpec{Name: "disk_size", Type: cty.Number, Required: false},
+		"disk_thin_provisioned":  &hcldec.AttrSpec{Name: "disk_thin_provisioned", Type: cty.Bool, Required: false},
+		"disk_eagerly_scrub":     &hcldec.AttrSpec{Name: "disk_eagerly_scrub", Type: cty.Bool, Required: false},
+		"disk_controller_index":  &hcldec.AttrSpec{Name: "disk_controller_index", Type: cty.Number, Required: false},
+		"disk_encryption_policy": &hcldec.AttrSpec{Name: "disk_encryption_policy", Type: cty.String, Required: false},
+		"disk_key_provider":      &hcldec.AttrSpec{Name: "disk_key_provider", Type: cty.String, Required: false},
 	}
 	return s
 }
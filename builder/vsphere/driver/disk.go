@@ -15,6 +15,8 @@ type Disk struct {
 	DiskEagerlyScrub    bool
 	DiskThinProvisioned bool
 	ControllerIndex     int
+	EncryptionPolicyID  string
+	KeyProvider         string
 }
 
 type StorageConfig struct {
@@ -48,6 +50,7 @@ func (c *StorageConfig) AddStorageDevices(existingDevices object.VirtualDeviceLi
 		controllers = append(controllers, controller)
 	}
 
+	encrypted := make(map[int32]Disk)
 	for _, dc := range c.Storage {
 		disk := &types.VirtualDisk{
 			VirtualDevice: types.VirtualDevice{
@@ -64,9 +67,63 @@ func (c *StorageConfig) AddStorageDevices(existingDevices object.VirtualDeviceLi
 		existingDevices.AssignController(disk, controllers[dc.ControllerIndex])
 		existingDevices = append(existingDevices, disk)
 		newDevices = append(newDevices, disk)
+		if dc.EncryptionPolicyID != "" {
+			encrypted[disk.Key] = dc
+		}
+	}
+
+	specs, err := newDevices.ConfigSpec(types.VirtualDeviceConfigSpecOperationAdd)
+	if err != nil {
+		return nil, err
 	}
 
-	return newDevices.ConfigSpec(types.VirtualDeviceConfigSpecOperationAdd)
+	for _, s := range specs {
+		spec := s.GetVirtualDeviceConfigSpec()
+		dc, ok := encrypted[spec.Device.GetVirtualDevice().Key]
+		if !ok {
+			continue
+		}
+		spec.Profile = storagePolicySpec(dc.EncryptionPolicyID)
+		spec.Backing = &types.VirtualDeviceConfigSpecBackingSpec{
+			Crypto: encryptSpec(dc.KeyProvider),
+		}
+	}
+
+	return specs, nil
+}
+
+// vSphere requires the virtual machine home to be encrypted when any of its
+// disks is, so it uses the settings of the first encrypted disk.
+func (c *StorageConfig) homeEncryption() (policyID string, keyProvider string) {
+	for _, dc := range c.Storage {
+		if dc.EncryptionPolicyID != "" {
+			return dc.EncryptionPolicyID, dc.KeyProvider
+		}
+	}
+	return "", ""
+}
+
+// Returns the locators that encrypt the existing disks of a virtual machine
+// while it is cloned, so the disks of the clone are not left unencrypted in an
+// encrypted virtual machine home. Disks that are already encrypted are kept
+// as they are.
+func encryptDiskLocators(devices object.VirtualDeviceList, datastore types.ManagedObjectReference, policyID string, keyProvider string) []types.VirtualMachineRelocateSpecDiskLocator {
+	var locators []types.VirtualMachineRelocateSpecDiskLocator
+	for _, device := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		disk := device.(*types.VirtualDisk)
+		if backing, ok := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo); ok && backing.KeyId != nil {
+			continue
+		}
+		locators = append(locators, types.VirtualMachineRelocateSpecDiskLocator{
+			DiskId:    disk.Key,
+			Datastore: datastore,
+			Profile:   storagePolicySpec(policyID),
+			Backing: &types.VirtualMachineRelocateSpecDiskLocatorBackingSpec{
+				Crypto: encryptSpec(keyProvider),
+			},
+		})
+	}
+	return locators
 }
 
 func findDisk(devices object.VirtualDeviceList) (*types.VirtualDisk, error) {
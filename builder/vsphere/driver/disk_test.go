@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 func TestAddStorageDevices(t *testing.T) {
@@ -47,3 +48,117 @@ func TestAddStorageDevices(t *testing.T) {
 		t.Fatalf("Expecting VirtualDeviceList to have 3 storage devices but had %d", len(storageConfigSpec))
 	}
 }
+
+func TestAddStorageDevices_Encryption(t *testing.T) {
+	config := &StorageConfig{
+		DiskControllerType: []string{"pvscsi"},
+		Storage: []Disk{
+			{
+				DiskSize: 3072,
+			},
+			{
+				DiskSize:           20480,
+				EncryptionPolicyID: "policy-id",
+				KeyProvider:        "nkp",
+			},
+		},
+	}
+
+	storageConfigSpec, err := config.AddStorageDevices(object.VirtualDeviceList{})
+	if err != nil {
+		t.Fatalf("unexpected error: %q", err.Error())
+	}
+
+	var encrypted int
+	for _, s := range storageConfigSpec {
+		spec := s.GetVirtualDeviceConfigSpec()
+		if spec.Backing == nil {
+			continue
+		}
+		encrypted++
+		disk := spec.Device.(*types.VirtualDisk)
+		if disk.CapacityInKB != 20480*1024 {
+			t.Fatalf("unexpected disk encrypted with capacity %d", disk.CapacityInKB)
+		}
+		profile := spec.Profile[0].(*types.VirtualMachineDefinedProfileSpec)
+		if profile.ProfileId != "policy-id" {
+			t.Fatalf("unexpected storage policy %q", profile.ProfileId)
+		}
+		crypto := spec.Backing.Crypto.(*types.CryptoSpecEncrypt)
+		if crypto.CryptoKeyId.ProviderId.Id != "nkp" {
+			t.Fatalf("unexpected key provider %q", crypto.CryptoKeyId.ProviderId.Id)
+		}
+	}
+	if encrypted != 1 {
+		t.Fatalf("Expecting 1 encrypted disk but had %d", encrypted)
+	}
+
+	policyID, keyProvider := config.homeEncryption()
+	if policyID != "policy-id" || keyProvider != "nkp" {
+		t.Fatalf("unexpected home encryption %q, %q", policyID, keyProvider)
+	}
+}
+
+func TestEncryptDiskLocators(t *testing.T) {
+	devices := object.VirtualDeviceList{
+		&types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{
+				Key:     2000,
+				Backing: &types.VirtualDiskFlatVer2BackingInfo{},
+			},
+		},
+		&types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{
+				Key: 2001,
+				Backing: &types.VirtualDiskFlatVer2BackingInfo{
+					KeyId: &types.CryptoKeyId{KeyId: "key-id"},
+				},
+			},
+		},
+		&types.VirtualCdrom{
+			VirtualDevice: types.VirtualDevice{
+				Key: 3000,
+			},
+		},
+	}
+	datastore := types.ManagedObjectReference{Type: "Datastore", Value: "datastore-1"}
+
+	locators := encryptDiskLocators(devices, datastore, "policy-id", "nkp")
+	if len(locators) != 1 {
+		t.Fatalf("Expecting 1 encrypted disk but had %d", len(locators))
+	}
+	locator := locators[0]
+	if locator.DiskId != 2000 || locator.Datastore != datastore {
+		t.Fatalf("unexpected disk %d on datastore %s", locator.DiskId, locator.Datastore.Value)
+	}
+	profile := locator.Profile[0].(*types.VirtualMachineDefinedProfileSpec)
+	if profile.ProfileId != "policy-id" {
+		t.Fatalf("unexpected storage policy %q", profile.ProfileId)
+	}
+	crypto := locator.Backing.Crypto.(*types.CryptoSpecEncrypt)
+	if crypto.CryptoKeyId.ProviderId.Id != "nkp" {
+		t.Fatalf("unexpected key provider %q", crypto.CryptoKeyId.ProviderId.Id)
+	}
+}
+
+func TestCloneCryptoSpec(t *testing.T) {
+	if _, ok := cloneCryptoSpec(nil, "nkp").(*types.CryptoSpecEncrypt); !ok {
+		t.Fatalf("expected an unencrypted virtual machine to be encrypted")
+	}
+
+	keyID := &types.CryptoKeyId{
+		KeyId:      "key-id",
+		ProviderId: &types.KeyProviderId{Id: "nkp"},
+	}
+	if spec := cloneCryptoSpec(keyID, "nkp"); spec != nil {
+		t.Fatalf("unexpected crypto spec %T for a virtual machine encrypted with the key provider", spec)
+	}
+
+	spec, ok := cloneCryptoSpec(keyID, "other-kp").(*types.CryptoSpecShallowRecrypt)
+	if !ok {
+		t.Fatalf("expected a virtual machine encrypted with another key provider to be recrypted")
+	}
+	if spec.NewKeyId.ProviderId.Id != "other-kp" {
+		t.Fatalf("unexpected key provider %q", spec.NewKeyId.ProviderId.Id)
+	}
+}
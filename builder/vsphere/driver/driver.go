@@ -5,6 +5,7 @@ package driver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
@@ -24,6 +25,8 @@ import (
 type Driver interface {
 	NewVM(ref *types.ManagedObjectReference) VirtualMachine
 	FindVM(name string) (VirtualMachine, error)
+	FindVMs(pattern string) ([]VirtualMachine, error)
+	IsStandaloneHost() bool
 	FindCluster(name string) (*Cluster, error)
 	PreCleanVM(ui packersdk.Ui, vmPath string, force bool, vsphereCluster string, vsphereHost string, vsphereResourcePool string) error
 	CreateVM(config *CreateConfig) (VirtualMachine, error)
@@ -42,10 +45,13 @@ type Driver interface {
 	FindNetworks(name string) ([]*Network, error)
 	NewResourcePool(ref *types.ManagedObjectReference) *ResourcePool
 	FindResourcePool(cluster string, host string, name string) (*ResourcePool, error)
+	FindStoragePolicyID(name string) (string, error)
 
 	FindContentLibraryByName(name string) (*Library, error)
 	FindContentLibraryItem(libraryId string, name string) (*library.Item, error)
 	FindContentLibraryFileDatastorePath(isoPath string) (string, error)
+	ProbeContentLibrarySource(uri string) (string, error)
+	PullContentLibraryFile(libraryName string, itemName string, fileName string, uri string, thumbprint string, checksum *library.Checksum) (string, bool, error)
 	UpdateContentLibraryItem(item *library.Item, name string, description string) error
 	Cleanup() (error, error)
 }
@@ -66,8 +72,9 @@ func NewVCenterDriver(ctx context.Context, client *govmomi.Client, vimClient *vi
 		client:    client,
 		vimClient: vimClient,
 		restClient: &RestClient{
-			client:      rest.NewClient(vimClient),
-			credentials: user,
+			client:         rest.NewClient(vimClient),
+			credentials:    user,
+			standaloneHost: !vimClient.IsVC(),
 		},
 		datacenter: datacenter,
 		finder:     finder,
@@ -121,8 +128,9 @@ func NewDriver(config *ConnectConfig) (Driver, error) {
 		client:    client,
 		vimClient: vimClient,
 		restClient: &RestClient{
-			client:      rest.NewClient(vimClient),
-			credentials: credentials,
+			client:         rest.NewClient(vimClient),
+			credentials:    credentials,
+			standaloneHost: !vimClient.IsVC(),
 		},
 		datacenter: datacenter,
 		finder:     finder,
@@ -130,8 +138,14 @@ func NewDriver(config *ConnectConfig) (Driver, error) {
 	return d, nil
 }
 
+// Returns true when connected to an ESXi host which is not managed by
+// vCenter Server.
+func (d *VCenterDriver) IsStandaloneHost() bool {
+	return !d.vimClient.IsVC()
+}
+
 func (d *VCenterDriver) Cleanup() (error, error) {
-	return d.restClient.client.Logout(d.ctx), d.client.SessionManager.Logout(d.ctx)
+	return d.restClient.Logout(d.ctx), d.client.SessionManager.Logout(d.ctx)
 }
 
 // The rest.Client requires vCenter.
@@ -139,14 +153,21 @@ func (d *VCenterDriver) Cleanup() (error, error) {
 // This will allow users without vCenter Server to use the other features that do not use the rest.Client.
 // To use the client login/logout must be done to create an authenticated session.
 type RestClient struct {
-	client      *rest.Client
-	credentials *url.Userinfo
+	client         *rest.Client
+	credentials    *url.Userinfo
+	standaloneHost bool
 }
 
 func (r *RestClient) Login(ctx context.Context) error {
+	if r.standaloneHost {
+		return errors.New("content libraries are not supported when connected to a standalone ESXi host")
+	}
 	return r.client.Login(ctx, r.credentials)
 }
 
 func (r *RestClient) Logout(ctx context.Context) error {
+	if r.standaloneHost {
+		return nil
+	}
 	return r.client.Logout(ctx)
 }
@@ -30,6 +30,34 @@ type DriverMock struct {
 
 	FindVMCalled bool
 	FindVMName   string
+
+	FindVMsCalled   bool
+	FindVMsPattern  string
+	FindVMsResponse []VirtualMachine
+	FindVMsErr      error
+
+	StandaloneHost bool
+
+	FindStoragePolicyIDCalled   bool
+	FindStoragePolicyIDName     string
+	FindStoragePolicyIDResponse string
+	FindStoragePolicyIDErr      error
+
+	ProbeContentLibrarySourceCalled   bool
+	ProbeContentLibrarySourceURI      string
+	ProbeContentLibrarySourceResponse string
+	ProbeContentLibrarySourceErr      error
+
+	PullContentLibraryFileCalled     bool
+	PullContentLibraryFileLibrary    string
+	PullContentLibraryFileItem       string
+	PullContentLibraryFileName       string
+	PullContentLibraryFileURI        string
+	PullContentLibraryFileThumbprint string
+	PullContentLibraryFileChecksum   *library.Checksum
+	PullContentLibraryFileResponse   string
+	PullContentLibraryFileDownloaded bool
+	PullContentLibraryFileErr        error
 }
 
 func NewDriverMock() *DriverMock {
@@ -59,6 +87,16 @@ func (d *DriverMock) FindVM(name string) (VirtualMachine, error) {
 	return d.VM, d.FindDatastoreErr
 }
 
+func (d *DriverMock) FindVMs(pattern string) ([]VirtualMachine, error) {
+	d.FindVMsCalled = true
+	d.FindVMsPattern = pattern
+	return d.FindVMsResponse, d.FindVMsErr
+}
+
+func (d *DriverMock) IsStandaloneHost() bool {
+	return d.StandaloneHost
+}
+
 func (d *DriverMock) FindCluster(name string) (*Cluster, error) {
 	return nil, nil
 }
@@ -111,6 +149,12 @@ func (d *DriverMock) FindResourcePool(cluster string, host string, name string)
 	return nil, nil
 }
 
+func (d *DriverMock) FindStoragePolicyID(name string) (string, error) {
+	d.FindStoragePolicyIDCalled = true
+	d.FindStoragePolicyIDName = name
+	return d.FindStoragePolicyIDResponse, d.FindStoragePolicyIDErr
+}
+
 func (d *DriverMock) FindContentLibraryByName(name string) (*Library, error) { return nil, nil }
 
 func (d *DriverMock) FindContentLibraryItem(libraryId string, name string) (*library.Item, error) {
@@ -121,6 +165,23 @@ func (d *DriverMock) FindContentLibraryFileDatastorePath(isoPath string) (string
 	return "", nil
 }
 
+func (d *DriverMock) ProbeContentLibrarySource(uri string) (string, error) {
+	d.ProbeContentLibrarySourceCalled = true
+	d.ProbeContentLibrarySourceURI = uri
+	return d.ProbeContentLibrarySourceResponse, d.ProbeContentLibrarySourceErr
+}
+
+func (d *DriverMock) PullContentLibraryFile(libraryName string, itemName string, fileName string, uri string, thumbprint string, checksum *library.Checksum) (string, bool, error) {
+	d.PullContentLibraryFileCalled = true
+	d.PullContentLibraryFileLibrary = libraryName
+	d.PullContentLibraryFileItem = itemName
+	d.PullContentLibraryFileName = fileName
+	d.PullContentLibraryFileURI = uri
+	d.PullContentLibraryFileThumbprint = thumbprint
+	d.PullContentLibraryFileChecksum = checksum
+	return d.PullContentLibraryFileResponse, d.PullContentLibraryFileDownloaded, d.PullContentLibraryFileErr
+}
+
 func (d *DriverMock) UpdateContentLibraryItem(item *library.Item, name string, description string) error {
 	return nil
 }
@@ -164,8 +164,9 @@ func (s *VCenterSimulator) NewSimulatorDriver() (*VCenterDriver, error) {
 		client:    client,
 		vimClient: vimClient,
 		restClient: &RestClient{
-			client:      rest.NewClient(vimClient),
-			credentials: user,
+			client:         rest.NewClient(vimClient),
+			credentials:    user,
+			standaloneHost: !vimClient.IsVC(),
 		},
 		datacenter: datacenter,
 		finder:     finder,
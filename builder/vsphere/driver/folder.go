@@ -27,6 +27,10 @@ func (d *VCenterDriver) NewFolder(ref *types.ManagedObjectReference) *Folder {
 }
 
 func (d *VCenterDriver) FindFolder(name string) (*Folder, error) {
+	if name != "" && d.IsStandaloneHost() {
+		return nil, fmt.Errorf("folders are not supported when connected to a standalone ESXi host")
+	}
+
 	if name != "" {
 		// create folders if they don't exist
 		parent := ""
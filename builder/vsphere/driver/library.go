@@ -8,6 +8,7 @@ import (
 	"log"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/vmware/govmomi/vapi/library"
 )
@@ -100,6 +101,142 @@ func (d *VCenterDriver) UpdateContentLibraryItem(item *library.Item, name string
 	return lm.UpdateLibraryItem(d.ctx, item)
 }
 
+// Returns the thumbprint of the certificate of an HTTPS source that is not
+// trusted by vCenter Server, or an empty string if the source can be
+// downloaded without accepting its certificate.
+func (d *VCenterDriver) ProbeContentLibrarySource(uri string) (string, error) {
+	err := d.restClient.Login(d.ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = d.restClient.Logout(d.ctx)
+	}()
+
+	lm := library.NewManager(d.restClient.client)
+	probe, err := lm.ProbeTransferEndpoint(d.ctx, library.TransferEndpoint{URI: uri})
+	if err != nil {
+		return "", err
+	}
+	switch probe.Status {
+	case "SUCCESS":
+		return "", nil
+	case "CERTIFICATE_ERROR":
+		return probe.SSLThumbprint, nil
+	}
+
+	var messages []string
+	for _, message := range probe.ErrorMessages {
+		messages = append(messages, message.DefaultMessage)
+	}
+	return "", fmt.Errorf("vCenter Server cannot download %s: %s", uri, strings.Join(messages, "; "))
+}
+
+// Has vCenter Server download the file from the URI to the library item, which
+// is created when it does not exist. The download is only skipped when the item
+// already contains the file and vCenter Server reports a checksum matching the
+// expected one. The thumbprint is that of an untrusted certificate
+// accepted for the URI. Returns the content library path of the file and
+// whether it was downloaded.
+func (d *VCenterDriver) PullContentLibraryFile(libraryName string, itemName string, fileName string, uri string, thumbprint string, checksum *library.Checksum) (string, bool, error) {
+	err := d.restClient.Login(d.ctx)
+	if err != nil {
+		return "", false, err
+	}
+	defer func() {
+		_ = d.restClient.Logout(d.ctx)
+	}()
+
+	lib, err := d.FindContentLibraryByName(libraryName)
+	if err != nil {
+		return "", false, err
+	}
+	if lib.library.Type != "LOCAL" {
+		return "", false, fmt.Errorf("cannot download to the content library %s of type %s; "+
+			"the content library must be of type LOCAL", libraryName, lib.library.Type)
+	}
+
+	libraryPath := path.Join(libraryName, itemName, fileName)
+	lm := library.NewManager(d.restClient.client)
+
+	created := false
+	item, err := d.FindContentLibraryItem(lib.library.ID, itemName)
+	if err != nil {
+		id, err := lm.CreateLibraryItem(d.ctx, library.Item{
+			Name:      itemName,
+			Type:      library.ItemTypeISO,
+			LibraryID: lib.library.ID,
+		})
+		if err != nil {
+			return "", false, err
+		}
+		item, err = lm.GetLibraryItem(d.ctx, id)
+		if err != nil {
+			return "", false, err
+		}
+		created = true
+	} else {
+		files, err := lm.ListLibraryItemFiles(d.ctx, item.ID)
+		if err != nil {
+			return "", false, err
+		}
+		for _, file := range files {
+			if file.Name == fileName && checksumVerified(file.Checksum, checksum) {
+				return libraryPath, false, nil
+			}
+		}
+	}
+
+	err = d.pullLibraryItemFile(lm, item.ID, fileName, uri, thumbprint, checksum)
+	if err != nil {
+		if created {
+			_ = lm.DeleteLibraryItem(d.ctx, item)
+		}
+		return "", false, err
+	}
+	return libraryPath, true, nil
+}
+
+func (d *VCenterDriver) pullLibraryItemFile(lm *library.Manager, itemID string, fileName string, uri string, thumbprint string, checksum *library.Checksum) error {
+	source := library.TransferEndpoint{
+		URI:                      uri,
+		SSLCertificateThumbprint: thumbprint,
+	}
+
+	session, err := lm.CreateLibraryItemUpdateSession(d.ctx, library.Session{
+		LibraryItemID: itemID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = lm.AddLibraryItemFile(d.ctx, session, library.UpdateFile{
+		Name:           fileName,
+		SourceType:     "PULL",
+		SourceEndpoint: &source,
+		Checksum:       checksum,
+	})
+	if err != nil {
+		_ = lm.CancelLibraryItemUpdateSession(d.ctx, session)
+		return err
+	}
+
+	// The session remains active until vCenter Server has finished the download.
+	if err = lm.CompleteLibraryItemUpdateSession(d.ctx, session); err != nil {
+		return err
+	}
+	return lm.WaitOnLibraryItemUpdateSession(d.ctx, session, 3*time.Second, nil)
+}
+
+// A file is only known to match the expected checksum when vCenter Server
+// reports a checksum of the same algorithm.
+func checksumVerified(actual *library.Checksum, expected *library.Checksum) bool {
+	if actual == nil || expected == nil || !strings.EqualFold(actual.Algorithm, expected.Algorithm) {
+		return false
+	}
+	return strings.EqualFold(actual.Checksum, expected.Checksum)
+}
+
 type LibraryFilePath struct {
 	path string
 }
@@ -3,7 +3,17 @@
 
 package driver
 
-import "testing"
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/library"
+	_ "github.com/vmware/govmomi/vapi/simulator"
+)
 
 func TestLibraryFilePath(t *testing.T) {
 	tc := []struct {
@@ -63,3 +73,142 @@ func TestLibraryFilePath(t *testing.T) {
 		}
 	}
 }
+
+func TestVCenterDriver_PullContentLibraryFile(t *testing.T) {
+	sim, err := NewVCenterSimulator()
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	iso := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "iso content")
+	}))
+	defer iso.Close()
+
+	_, datastore := sim.ChooseSimulatorPreCreatedDatastore()
+	d := sim.driver
+	// The content library endpoints require the credentials of the simulator.
+	d.restClient.credentials = simulator.DefaultLogin
+	if err := d.restClient.Login(d.ctx); err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	lm := library.NewManager(d.restClient.client)
+	libraryID, err := lm.CreateLibrary(d.ctx, library.Library{
+		Name: "Packer",
+		Type: "LOCAL",
+		Storage: []library.StorageBackings{{
+			DatastoreID: datastore.Self.Value,
+			Type:        "DATASTORE",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+
+	uri := iso.URL + "/ubuntu-server.iso"
+	thumbprint, err := d.ProbeContentLibrarySource(uri)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if thumbprint != "" {
+		t.Fatalf("unexpected thumbprint %s", thumbprint)
+	}
+	libraryPath, downloaded, err := d.PullContentLibraryFile("Packer", "ubuntu-server", "ubuntu-server.iso", uri, "", nil)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if libraryPath != "Packer/ubuntu-server/ubuntu-server.iso" {
+		t.Fatalf("unexpected content library path %s", libraryPath)
+	}
+	if !downloaded {
+		t.Fatalf("expected the file to be downloaded")
+	}
+
+	// The simulator completes the session before the file is downloaded.
+	if err := d.restClient.Login(d.ctx); err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	item, err := d.FindContentLibraryItem(libraryID, "ubuntu-server")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	for i := 0; ; i++ {
+		files, err := lm.ListLibraryItemFiles(d.ctx, item.ID)
+		if err != nil {
+			t.Fatalf("should not fail: %s", err.Error())
+		}
+		if len(files) == 1 {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("unexpected number of files: %d", len(files))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The simulator does not report the checksum of the file, so it
+	// cannot be verified.
+	checksum := &library.Checksum{Algorithm: "SHA256", Checksum: "abcdef"}
+	_, downloaded, err = d.PullContentLibraryFile("Packer", "ubuntu-server", "ubuntu-server.iso", uri, "", checksum)
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if !downloaded {
+		t.Fatalf("expected the file with an unverified checksum to be downloaded again")
+	}
+
+	if _, _, err := d.PullContentLibraryFile("missing", "ubuntu-server", "ubuntu-server.iso", uri, "", nil); err == nil {
+		t.Fatalf("should fail to download to a missing content library")
+	}
+}
+
+func TestVCenterDriver_ProbeContentLibrarySource(t *testing.T) {
+	sim, err := NewVCenterSimulator()
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	iso := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "iso content")
+	}))
+	defer iso.Close()
+
+	d := sim.driver
+	d.restClient.credentials = simulator.DefaultLogin
+
+	thumbprint, err := d.ProbeContentLibrarySource(iso.URL + "/ubuntu-server.iso")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if thumbprint == "" {
+		t.Fatalf("expected the thumbprint of the untrusted certificate")
+	}
+
+	if _, err := d.ProbeContentLibrarySource("ftp://example.com/ubuntu-server.iso"); err == nil {
+		t.Fatalf("should fail to probe an unsupported URL")
+	}
+}
+
+func TestChecksumVerified(t *testing.T) {
+	sha256 := &library.Checksum{Algorithm: "SHA256", Checksum: "abcdef"}
+	tc := []struct {
+		actual   *library.Checksum
+		expected *library.Checksum
+		verified bool
+	}{
+		{actual: nil, expected: sha256, verified: false},
+		{actual: sha256, expected: nil, verified: false},
+		{actual: nil, expected: nil, verified: false},
+		{actual: &library.Checksum{Algorithm: "SHA1", Checksum: "abcdef"}, expected: sha256, verified: false},
+		{actual: &library.Checksum{Algorithm: "sha256", Checksum: "ABCDEF"}, expected: sha256, verified: true},
+		{actual: &library.Checksum{Algorithm: "SHA256", Checksum: "123456"}, expected: sha256, verified: false},
+	}
+
+	for _, c := range tc {
+		if verified := checksumVerified(c.actual, c.expected); verified != c.verified {
+			t.Fatalf("unexpected verification %t for %v and %v", verified, c.actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package driver
+
+import (
+	"github.com/vmware/govmomi/pbm"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func (d *VCenterDriver) FindStoragePolicyID(name string) (string, error) {
+	c, err := pbm.NewClient(d.ctx, d.vimClient)
+	if err != nil {
+		return "", err
+	}
+	return c.ProfileIDByName(d.ctx, name)
+}
+
+func storagePolicySpec(policyID string) []types.BaseVirtualMachineProfileSpec {
+	return []types.BaseVirtualMachineProfileSpec{
+		&types.VirtualMachineDefinedProfileSpec{
+			ProfileId: policyID,
+		},
+	}
+}
+
+// An empty key ID lets the key provider generate the key at the time of
+// encryption.
+func encryptSpec(keyProvider string) *types.CryptoSpecEncrypt {
+	return &types.CryptoSpecEncrypt{
+		CryptoKeyId: types.CryptoKeyId{
+			ProviderId: &types.KeyProviderId{
+				Id: keyProvider,
+			},
+		},
+	}
+}
+
+// vSphere refuses to encrypt a virtual machine that is already encrypted, so
+// the clone of an encrypted virtual machine keeps its key, or is shallow
+// recrypted when the key provider differs.
+func cloneCryptoSpec(keyID *types.CryptoKeyId, keyProvider string) types.BaseCryptoSpec {
+	if keyID == nil {
+		return encryptSpec(keyProvider)
+	}
+	if keyID.ProviderId != nil && keyID.ProviderId.Id == keyProvider {
+		return nil
+	}
+	return &types.CryptoSpecShallowRecrypt{
+		NewKeyId: types.CryptoKeyId{
+			ProviderId: &types.KeyProviderId{
+				Id: keyProvider,
+			},
+		},
+	}
+}
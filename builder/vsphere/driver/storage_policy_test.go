@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package driver
+
+import (
+	"testing"
+
+	_ "github.com/vmware/govmomi/pbm/simulator"
+)
+
+func TestVCenterDriver_FindStoragePolicyID(t *testing.T) {
+	sim, err := NewVCenterSimulator()
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	id, err := sim.driver.FindStoragePolicyID("VM Encryption Policy")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if id == "" {
+		t.Fatalf("expected storage policy ID")
+	}
+
+	if _, err := sim.driver.FindStoragePolicyID("unknown"); err == nil {
+		t.Fatalf("should fail to find unknown storage policy")
+	}
+}
@@ -61,6 +61,8 @@ type VirtualMachine interface {
 	NewOvfManager() *ovf.Manager
 	GetOvfExportOptions(m *ovf.Manager) ([]types.OvfOptionInfo, error)
 	Datacenter() *object.Datacenter
+	Name() string
+	HasBuildMarker() (bool, error)
 
 	AddCdrom(controllerType string, datastoreIsoPath string) error
 	CreateCdrom(c *types.VirtualController) (*types.VirtualCdrom, error)
@@ -70,6 +72,11 @@ type VirtualMachine interface {
 	FindSATAController() (*types.VirtualAHCIController, error)
 }
 
+// BuildMarkerKey is the advanced configuration key set on a virtual machine
+// while a build is in progress. Virtual machines that still carry it were left
+// behind by a build that did not complete.
+const BuildMarkerKey = "packer.build.inProgress"
+
 type VirtualMachineDriver struct {
 	vm     *object.VirtualMachine
 	driver *VCenterDriver
@@ -89,6 +96,7 @@ type CloneConfig struct {
 	VAppProperties  map[string]string
 	PrimaryDiskSize int64
 	StorageConfig   StorageConfig
+	BuildMarker     bool
 }
 
 type HardwareConfig struct {
@@ -108,6 +116,8 @@ type HardwareConfig struct {
 	Firmware            string
 	ForceBIOSSetup      bool
 	VTPMEnabled         bool
+	SwapPlacement       string
+	MigrateEncryption   string
 }
 
 type NIC struct {
@@ -130,6 +140,7 @@ type CreateConfig struct {
 	USBController []string
 	Version       uint // example: 10
 	StorageConfig StorageConfig
+	BuildMarker   bool
 }
 
 func (d *VCenterDriver) NewVM(ref *types.ManagedObjectReference) VirtualMachine {
@@ -150,6 +161,25 @@ func (d *VCenterDriver) FindVM(name string) (VirtualMachine, error) {
 	}, nil
 }
 
+func (d *VCenterDriver) FindVMs(pattern string) ([]VirtualMachine, error) {
+	vms, err := d.finder.VirtualMachineList(d.ctx, pattern)
+	if err != nil {
+		if _, ok := err.(*find.NotFoundError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var result []VirtualMachine
+	for _, vm := range vms {
+		result = append(result, &VirtualMachineDriver{
+			vm:     vm,
+			driver: d,
+		})
+	}
+	return result, nil
+}
+
 func (d *VCenterDriver) PreCleanVM(ui packersdk.Ui, vmPath string, force bool, vsphereCluster string, vsphereHost string, vsphereResourcePool string) error {
 	vm, err := d.FindVM(vmPath)
 	if err != nil {
@@ -261,6 +291,15 @@ func (d *VCenterDriver) CreateVM(config *CreateConfig) (VirtualMachine, error) {
 		VmPathName: fmt.Sprintf("[%s]", datastore.Name()),
 	}
 
+	if policyID, keyProvider := config.StorageConfig.homeEncryption(); policyID != "" {
+		createSpec.VmProfile = storagePolicySpec(policyID)
+		createSpec.Crypto = encryptSpec(keyProvider)
+	}
+
+	if config.BuildMarker {
+		createSpec.ExtraConfig = buildMarkerOptions()
+	}
+
 	task, err := folder.folder.CreateVM(d.ctx, createSpec, resourcePool.pool, host)
 	if err != nil {
 		return nil, err
@@ -312,6 +351,10 @@ func (vm *VirtualMachineDriver) FloppyDevices() (object.VirtualDeviceList, error
 }
 
 func (vm *VirtualMachineDriver) Clone(ctx context.Context, config *CloneConfig) (VirtualMachine, error) {
+	if vm.driver.IsStandaloneHost() {
+		return vm.cloneOnHost(ctx, config)
+	}
+
 	folder, err := vm.driver.FindFolder(config.Folder)
 	if err != nil {
 		return nil, fmt.Errorf("Error finding filder: %s", err)
@@ -360,13 +403,57 @@ func (vm *VirtualMachineDriver) Clone(ctx context.Context, config *CloneConfig)
 		cloneSpec.Snapshot = tpl.Snapshot.CurrentSnapshot
 	}
 
+	configSpec, err := vm.cloneConfigSpec(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	cloneSpec.Config = configSpec
+
+	if policyID, keyProvider := config.StorageConfig.homeEncryption(); policyID != "" {
+		devices, err := vm.vm.Device(vm.driver.ctx)
+		if err != nil {
+			return nil, err
+		}
+		cloneSpec.Location.Disk = encryptDiskLocators(devices, datastoreRef, policyID, keyProvider)
+	}
+
+	task, err := vm.vm.Clone(vm.driver.ctx, folder.folder, config.Name, cloneSpec)
+	if err != nil {
+		return nil, fmt.Errorf("Error calling vm.vm.Clone task: %s", err)
+	}
+
+	info, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			err = task.Cancel(context.TODO())
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("Error waiting for vm Clone to complete: %s", err)
+	}
+
+	vmRef, ok := info.Result.(types.ManagedObjectReference)
+	if !ok {
+		return nil, fmt.Errorf("something went wrong when cloning the VM")
+	}
+
+	created := vm.driver.NewVM(&vmRef)
+	return created, nil
+}
+
+// Returns the changes applied to the clone of the virtual machine, based on
+// the devices of the virtual machine.
+func (vm *VirtualMachineDriver) cloneConfigSpec(ctx context.Context, config *CloneConfig) (*types.VirtualMachineConfigSpec, error) {
 	var configSpec types.VirtualMachineConfigSpec
-	cloneSpec.Config = &configSpec
 
 	if config.Annotation != "" {
 		configSpec.Annotation = config.Annotation
 	}
 
+	if config.BuildMarker {
+		configSpec.ExtraConfig = buildMarkerOptions()
+	}
+
 	devices, err := vm.vm.Device(vm.driver.ctx)
 	if err != nil {
 		return nil, err
@@ -394,6 +481,19 @@ func (vm *VirtualMachineDriver) Clone(ctx context.Context, config *CloneConfig)
 	}
 	configSpec.DeviceChange = append(configSpec.DeviceChange, storageConfigSpec...)
 
+	if policyID, keyProvider := config.StorageConfig.homeEncryption(); policyID != "" {
+		info, err := vm.Info("config.keyId")
+		if err != nil {
+			return nil, err
+		}
+		var keyID *types.CryptoKeyId
+		if info.Config != nil {
+			keyID = info.Config.KeyId
+		}
+		configSpec.VmProfile = storagePolicySpec(policyID)
+		configSpec.Crypto = cloneCryptoSpec(keyID, keyProvider)
+	}
+
 	if config.Network != "" {
 		net, err := vm.driver.FindNetwork(config.Network)
 		if err != nil {
@@ -436,28 +536,7 @@ func (vm *VirtualMachineDriver) Clone(ctx context.Context, config *CloneConfig)
 	}
 	configSpec.VAppConfig = vAppConfig
 
-	task, err := vm.vm.Clone(vm.driver.ctx, folder.folder, config.Name, cloneSpec)
-	if err != nil {
-		return nil, fmt.Errorf("Error calling vm.vm.Clone task: %s", err)
-	}
-
-	info, err := task.WaitForResult(ctx, nil)
-	if err != nil {
-		if ctx.Err() == context.Canceled {
-			err = task.Cancel(context.TODO())
-			return nil, err
-		}
-
-		return nil, fmt.Errorf("Error waiting for vm Clone to complete: %s", err)
-	}
-
-	vmRef, ok := info.Result.(types.ManagedObjectReference)
-	if !ok {
-		return nil, fmt.Errorf("something went wrong when cloning the VM")
-	}
-
-	created := vm.driver.NewVM(&vmRef)
-	return created, nil
+	return &configSpec, nil
 }
 
 func (vm *VirtualMachineDriver) updateVAppConfig(ctx context.Context, newProps map[string]string) (*types.VmConfigSpec, error) {
@@ -565,6 +644,8 @@ func (vm *VirtualMachineDriver) Configure(config *HardwareConfig) error {
 
 	confSpec.CpuHotAddEnabled = &config.CpuHotAddEnabled
 	confSpec.MemoryHotAddEnabled = &config.MemoryHotAddEnabled
+	confSpec.SwapPlacement = config.SwapPlacement
+	confSpec.MigrateEncryption = config.MigrateEncryption
 
 	if config.Displays == 0 {
 		config.Displays = 1
@@ -1131,6 +1212,18 @@ func (vm *VirtualMachineDriver) SetBootOrder(order []string) error {
 		return err
 	}
 
+	// Device names which do not match a device are ignored by BootOrder,
+	// which would silently shorten the boot order.
+	for _, name := range order {
+		switch name {
+		case object.DeviceTypeNone, object.DeviceTypeCdrom, object.DeviceTypeDisk, object.DeviceTypeEthernet, object.DeviceTypeFloppy:
+			continue
+		}
+		if len(devices.BootOrder([]string{name})) == 0 {
+			return fmt.Errorf("boot device %q not found", name)
+		}
+	}
+
 	bootOptions := types.VirtualMachineBootOptions{
 		BootOrder: devices.BootOrder(order),
 	}
@@ -1247,6 +1340,36 @@ func (vm *VirtualMachineDriver) Datacenter() *object.Datacenter {
 	return vm.driver.datacenter
 }
 
+func (vm *VirtualMachineDriver) Name() string {
+	return vm.vm.Name()
+}
+
+func (vm *VirtualMachineDriver) HasBuildMarker() (bool, error) {
+	info, err := vm.Info("config.extraConfig")
+	if err != nil {
+		return false, err
+	}
+	if info.Config == nil {
+		return false, nil
+	}
+
+	for _, option := range info.Config.ExtraConfig {
+		if o := option.GetOptionValue(); o.Key == BuildMarkerKey {
+			return o.Value != "", nil
+		}
+	}
+	return false, nil
+}
+
+func buildMarkerOptions() []types.BaseOptionValue {
+	return []types.BaseOptionValue{
+		&types.OptionValue{
+			Key:   BuildMarkerKey,
+			Value: "TRUE",
+		},
+	}
+}
+
 func (vm *VirtualMachineDriver) FindContentLibraryTemplateDatastoreName(library string) ([]string, error) {
 	err := vm.driver.restClient.Login(vm.driver.ctx)
 	if err != nil {
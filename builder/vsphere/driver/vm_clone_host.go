@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// A standalone ESXi host does not support cloning virtual machines. The clone
+// is created by copying the files of the virtual machine to a new directory
+// and registering the copy, then the clone changes are applied with a
+// reconfiguration.
+func (vm *VirtualMachineDriver) cloneOnHost(ctx context.Context, config *CloneConfig) (VirtualMachine, error) {
+	if config.LinkedClone {
+		return nil, errors.New("linked clones are not supported when connected to a standalone ESXi host")
+	}
+
+	folder, err := vm.driver.FindFolder(config.Folder)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding folder: %s", err)
+	}
+
+	pool, err := vm.driver.FindResourcePool(config.Cluster, config.Host, config.ResourcePool)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding resource pool: %s", err)
+	}
+
+	datastore, err := vm.driver.FindDatastore(config.Datastore, config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding datastore: %s", err)
+	}
+
+	info, err := vm.Info("config.files", "config.hardware.device", "layoutEx")
+	if err != nil {
+		return nil, err
+	}
+
+	files, disks, err := cloneFiles(info)
+	if err != nil {
+		return nil, err
+	}
+
+	dc := vm.driver.datacenter
+	target := object.DatastorePath{
+		Datastore: datastore.Name(),
+		Path:      config.Name,
+	}
+
+	fm := object.NewFileManager(vm.driver.vimClient)
+	if err := fm.MakeDirectory(ctx, target.String(), dc, false); err != nil {
+		return nil, fmt.Errorf("Error creating virtual machine directory: %s", err)
+	}
+
+	created, err := vm.copyAndRegister(ctx, config.Name, target, files, disks, folder, pool)
+	if err != nil {
+		if task, err := fm.DeleteDatastoreFile(ctx, target.String(), dc); err == nil {
+			_ = task.Wait(ctx)
+		}
+		return nil, err
+	}
+
+	configSpec, err := created.cloneConfigSpec(ctx, config)
+	if err == nil {
+		// Generate a new UUID instead of asking whether the virtual machine
+		// was moved or copied when powered on.
+		configSpec.ExtraConfig = append(configSpec.ExtraConfig, &types.OptionValue{
+			Key:   "uuid.action",
+			Value: "create",
+		})
+		err = created.Reconfigure(*configSpec)
+	}
+	if err != nil {
+		_ = created.Destroy()
+		return nil, fmt.Errorf("Error configuring the clone: %s", err)
+	}
+
+	return created, nil
+}
+
+// Returns the configuration and NVRAM files, and the disks of the virtual
+// machine. The configuration file is always the first file.
+func cloneFiles(info *mo.VirtualMachine) ([]object.DatastorePath, []object.DatastorePath, error) {
+	var source object.DatastorePath
+	if !source.FromString(info.Config.Files.VmPathName) {
+		return nil, nil, fmt.Errorf("invalid virtual machine path %q", info.Config.Files.VmPathName)
+	}
+	dir := path.Dir(source.Path)
+
+	files := []object.DatastorePath{source}
+	if info.LayoutEx != nil {
+		for _, file := range info.LayoutEx.File {
+			var f object.DatastorePath
+			if file.Type == string(types.VirtualMachineFileLayoutExFileTypeNvram) && f.FromString(file.Name) {
+				files = append(files, object.DatastorePath{
+					Datastore: source.Datastore,
+					Path:      path.Join(dir, path.Base(f.Path)),
+				})
+			}
+		}
+	}
+
+	var disks []object.DatastorePath
+	for _, device := range object.VirtualDeviceList(info.Config.Hardware.Device).SelectByType((*types.VirtualDisk)(nil)) {
+		backing, ok := device.GetVirtualDevice().Backing.(types.BaseVirtualDeviceFileBackingInfo)
+		if !ok {
+			continue
+		}
+		var disk object.DatastorePath
+		fileName := backing.GetVirtualDeviceFileBackingInfo().FileName
+		if !disk.FromString(fileName) || disk.Datastore != source.Datastore || path.Dir(disk.Path) != dir {
+			return nil, nil, fmt.Errorf("disk %s must be in the virtual machine directory to be cloned on a standalone ESXi host", fileName)
+		}
+		disks = append(disks, disk)
+	}
+
+	return files, disks, nil
+}
+
+// The files keep their name, so the copied configuration refers to the copied
+// files.
+func (vm *VirtualMachineDriver) copyAndRegister(ctx context.Context, name string, target object.DatastorePath, files []object.DatastorePath, disks []object.DatastorePath, folder *Folder, pool *ResourcePool) (*VirtualMachineDriver, error) {
+	dc := vm.driver.datacenter
+	targetPath := func(p object.DatastorePath) string {
+		dst := object.DatastorePath{
+			Datastore: target.Datastore,
+			Path:      path.Join(target.Path, path.Base(p.Path)),
+		}
+		return dst.String()
+	}
+
+	fm := object.NewFileManager(vm.driver.vimClient)
+	for _, file := range files {
+		task, err := fm.CopyDatastoreFile(ctx, file.String(), dc, targetPath(file), dc, false)
+		if err == nil {
+			err = task.Wait(ctx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error copying file %s: %s", file.String(), err)
+		}
+	}
+
+	vdm := object.NewVirtualDiskManager(vm.driver.vimClient)
+	for _, disk := range disks {
+		task, err := vdm.CopyVirtualDisk(ctx, disk.String(), dc, targetPath(disk), dc, nil, false)
+		if err == nil {
+			err = task.Wait(ctx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error copying disk %s: %s", disk.String(), err)
+		}
+	}
+
+	task, err := folder.folder.RegisterVM(ctx, targetPath(files[0]), name, false, pool.pool, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error registering virtual machine: %s", err)
+	}
+	result, err := task.WaitForResult(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error registering virtual machine: %s", err)
+	}
+
+	vmRef, ok := result.Result.(types.ManagedObjectReference)
+	if !ok {
+		return nil, fmt.Errorf("something went wrong when registering the VM")
+	}
+
+	return vm.driver.NewVM(&vmRef).(*VirtualMachineDriver), nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package driver
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// MakeDirectoryWithNvram changes the behavior of simulator.FileManager for a
+// single call, as the simulator expects the NVRAM file of a registered virtual
+// machine to be named after the virtual machine and its log file to exist.
+type MakeDirectoryWithNvram struct {
+	*simulator.FileManager
+	dir string
+}
+
+func (f *MakeDirectoryWithNvram) MakeDirectory(req *types.MakeDirectory) soap.HasFault {
+	res := f.FileManager.MakeDirectory(req)
+	// Restore the simulator.FileManager, which is used by other managers.
+	simulator.Map.Put(f.FileManager)
+	var p object.DatastorePath
+	if p.FromString(req.Name) {
+		for _, name := range []string{path.Base(p.Path) + ".nvram", "vmware.log"} {
+			_ = os.WriteFile(filepath.Join(f.dir, p.Path, name), nil, 0644)
+		}
+	}
+	return res
+}
+
+func TestVCenterDriver_StandaloneHost(t *testing.T) {
+	sim, err := NewCustomVCenterSimulator(simulator.ESX())
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	if !sim.driver.IsStandaloneHost() {
+		t.Fatalf("driver should be connected to a standalone host")
+	}
+
+	if _, err := sim.driver.FindFolder(""); err != nil {
+		t.Fatalf("should find the root folder: %s", err.Error())
+	}
+	if _, err := sim.driver.FindFolder("folder"); err == nil {
+		t.Fatalf("folders should not be supported")
+	}
+
+	if _, err := sim.driver.FindContentLibraryFileDatastorePath("[library] item/file.iso"); err == nil {
+		t.Fatalf("content libraries should not be supported")
+	}
+
+	if errRest, _ := sim.driver.Cleanup(); errRest != nil {
+		t.Fatalf("should not fail to close REST session: %s", errRest.Error())
+	}
+}
+
+func TestVirtualMachineDriver_CloneOnHost(t *testing.T) {
+	sim, err := NewCustomVCenterSimulator(simulator.ESX())
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	template, _ := sim.ChooseSimulatorPreCreatedVM()
+	host, _ := sim.ChooseSimulatorPreCreatedHost()
+	hostInfo, err := host.Info("name")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	_, ds := sim.ChooseSimulatorPreCreatedDatastore()
+
+	fm := simulator.Map.Get(*sim.driver.vimClient.ServiceContent.FileManager).(*simulator.FileManager)
+	simulator.Map.Put(&MakeDirectoryWithNvram{fm, ds.Info.GetDatastoreInfo().Url})
+
+	vm, err := template.Clone(context.TODO(), &CloneConfig{
+		Name:        "clone",
+		Host:        hostInfo.Name,
+		Datastore:   ds.Name,
+		Annotation:  "cloned on host",
+		BuildMarker: true,
+	})
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+
+	info, err := vm.Info("name", "config.annotation", "config.files")
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if info.Name != "clone" {
+		t.Fatalf("unexpected clone name %q", info.Name)
+	}
+	if info.Config.Annotation != "cloned on host" {
+		t.Fatalf("unexpected clone annotation %q", info.Config.Annotation)
+	}
+	marked, err := vm.HasBuildMarker()
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	if !marked {
+		t.Fatalf("clone should have the build marker")
+	}
+
+	if _, err := template.Clone(context.TODO(), &CloneConfig{Name: "linked", LinkedClone: true}); err == nil {
+		t.Fatalf("linked clones should not be supported")
+	}
+}
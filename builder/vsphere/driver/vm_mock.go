@@ -5,6 +5,7 @@ package driver
 
 import (
 	"context"
+	"errors"
 	"net"
 	"time"
 
@@ -70,6 +71,24 @@ type VirtualMachineMock struct {
 	CloneCalled bool
 	CloneConfig *CloneConfig
 	CloneError  error
+
+	SetBootOrderCalled bool
+	SetBootOrderOrder  []string
+	SetBootOrderErr    error
+
+	NameReturn string
+
+	WaitForShutdownCalled    bool
+	WaitForShutdownTimeout   time.Duration
+	WaitForShutdownPoweredOn bool
+	WaitForShutdownErr       error
+
+	HasBuildMarkerReturn bool
+	HasBuildMarkerErr    error
+
+	AddConfigParamsCalled bool
+	AddConfigParamsParams map[string]string
+	AddConfigParamsErr    error
 }
 
 func (vm *VirtualMachineMock) Info(params ...string) (*mo.VirtualMachine, error) {
@@ -153,7 +172,18 @@ func (vm *VirtualMachineMock) StartShutdown() error {
 }
 
 func (vm *VirtualMachineMock) WaitForShutdown(ctx context.Context, timeout time.Duration) error {
-	return nil
+	vm.WaitForShutdownCalled = true
+	vm.WaitForShutdownTimeout = timeout
+	if vm.WaitForShutdownPoweredOn {
+		// The virtual machine never powers off.
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(timeout):
+			return errors.New("Timeout while waiting for machine to shut down.")
+		}
+	}
+	return vm.WaitForShutdownErr
 }
 
 func (vm *VirtualMachineMock) CreateSnapshot(name string) error {
@@ -199,7 +229,9 @@ func (vm *VirtualMachineMock) AddFloppy(imgPath string) error {
 }
 
 func (vm *VirtualMachineMock) SetBootOrder(order []string) error {
-	return nil
+	vm.SetBootOrderCalled = true
+	vm.SetBootOrderOrder = order
+	return vm.SetBootOrderErr
 }
 
 func (vm *VirtualMachineMock) RemoveDevice(keepFiles bool, device ...types.BaseVirtualDevice) error {
@@ -214,7 +246,9 @@ func (vm *VirtualMachineMock) addDevice(device types.BaseVirtualDevice) error {
 }
 
 func (vm *VirtualMachineMock) AddConfigParams(params map[string]string, info *types.ToolsConfigInfo) error {
-	return nil
+	vm.AddConfigParamsCalled = true
+	vm.AddConfigParamsParams = params
+	return vm.AddConfigParamsErr
 }
 
 func (vm *VirtualMachineMock) AddFlag(ctx context.Context, info *types.VirtualMachineFlagInfo) error {
@@ -276,3 +310,11 @@ func (vm *VirtualMachineMock) EjectCdroms() error {
 func (vm *VirtualMachineMock) Datacenter() *object.Datacenter {
 	return nil
 }
+
+func (vm *VirtualMachineMock) Name() string {
+	return vm.NameReturn
+}
+
+func (vm *VirtualMachineMock) HasBuildMarker() (bool, error) {
+	return vm.HasBuildMarkerReturn, vm.HasBuildMarkerErr
+}
@@ -248,3 +248,81 @@ func TestVirtualMachineDriver_CloneWithMacAddress(t *testing.T) {
 		t.Fatalf("unexpected mac address")
 	}
 }
+
+func TestVirtualMachineDriver_BuildMarker(t *testing.T) {
+	sim, err := NewVCenterSimulator()
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	_, datastore := sim.ChooseSimulatorPreCreatedDatastore()
+
+	for _, name := range []string{"packer-marked", "packer-unmarked"} {
+		config := &CreateConfig{
+			Name:        name,
+			Host:        "DC0_H0",
+			Datastore:   datastore.Name,
+			BuildMarker: name == "packer-marked",
+		}
+		if _, err := sim.driver.CreateVM(config); err != nil {
+			t.Fatalf("unexpected error %s", err.Error())
+		}
+	}
+
+	vms, err := sim.driver.FindVMs("packer-*")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if len(vms) != 2 {
+		t.Fatalf("unexpected number of virtual machines: %d", len(vms))
+	}
+
+	for _, vm := range vms {
+		marked, err := vm.HasBuildMarker()
+		if err != nil {
+			t.Fatalf("unexpected error %s", err.Error())
+		}
+		if marked != (vm.Name() == "packer-marked") {
+			t.Fatalf("unexpected build marker %t for %s", marked, vm.Name())
+		}
+	}
+
+	vms, err = sim.driver.FindVMs("missing-*")
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	if len(vms) != 0 {
+		t.Fatalf("unexpected number of virtual machines: %d", len(vms))
+	}
+}
+
+func TestVirtualMachineDriver_SetBootOrder(t *testing.T) {
+	sim, err := NewVCenterSimulator()
+	if err != nil {
+		t.Fatalf("should not fail: %s", err.Error())
+	}
+	defer sim.Close()
+
+	vm, _ := sim.ChooseSimulatorPreCreatedVM()
+	devices, err := vm.Devices()
+	if err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+	disks := devices.SelectByType((*types.VirtualDisk)(nil))
+	if len(disks) == 0 {
+		t.Fatalf("expected a disk")
+	}
+
+	if err := vm.SetBootOrder([]string{"floppy", devices.Name(disks[0]), "cdrom"}); err != nil {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+
+	err = vm.SetBootOrder([]string{"disk-1000-9", "cdrom"})
+	if err == nil {
+		t.Fatalf("SetBootOrder should fail for an unknown device")
+	}
+	if err.Error() != `boot device "disk-1000-9" not found` {
+		t.Fatalf("unexpected error %s", err.Error())
+	}
+}
@@ -37,13 +37,40 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 	state.Put("hook", hook)
 	state.Put("ui", ui)
 
+	var vcenterOptions []string
+	if b.config.ConvertToTemplate {
+		vcenterOptions = append(vcenterOptions, "convert_to_template")
+	}
+	if b.config.ContentLibraryDestinationConfig != nil {
+		vcenterOptions = append(vcenterOptions, "content_library_destination")
+	}
+	if b.config.ISOContentLibrary != "" {
+		vcenterOptions = append(vcenterOptions, "iso_content_library")
+	}
+
 	var steps []multistep.Step
 
 	steps = append(steps,
 		&common.StepConnect{
-			Config: &b.config.ConnectConfig,
+			Config:         &b.config.ConnectConfig,
+			VCenterOptions: vcenterOptions,
 		},
-		&common.StepDownload{
+		&common.StepCleanOrphanedVMs{
+			Config:   &b.config.OrphanedVMConfig,
+			Location: &b.config.LocationConfig,
+		},
+	)
+
+	if b.config.ISOContentLibrary != "" {
+		steps = append(steps, &common.StepPullISO{
+			Config:             &b.config.RemoteISOConfig,
+			Url:                b.config.ISOUrls,
+			Checksum:           b.config.ISOChecksum,
+			InsecureConnection: b.config.InsecureConnection,
+			HeartbeatInterval:  b.config.HeartbeatInterval,
+		})
+	} else {
+		steps = append(steps, &common.StepDownload{
 			DownloadStep: &commonsteps.StepDownload{
 				Checksum:    b.config.ISOChecksum,
 				Description: "ISO",
@@ -56,7 +83,10 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			ResultKey: "iso_path",
 			Datastore: b.config.Datastore,
 			Host:      b.config.Host,
-		},
+		})
+	}
+
+	steps = append(steps,
 		&commonsteps.StepCreateCD{
 			Files:   b.config.CDConfig.CDFiles,
 			Content: b.config.CDConfig.CDContent,
@@ -68,9 +98,10 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			SetHostForDatastoreUploads: b.config.SetHostForDatastoreUploads,
 		},
 		&StepCreateVM{
-			Config:   &b.config.CreateConfig,
-			Location: &b.config.LocationConfig,
-			Force:    b.config.PackerConfig.PackerForce,
+			Config:      &b.config.CreateConfig,
+			Location:    &b.config.LocationConfig,
+			Force:       b.config.PackerConfig.PackerForce,
+			BuildMarker: b.config.OrphanedVMAction != "",
 		},
 		&common.StepConfigureHardware{
 			Config: &b.config.HardwareConfig,
@@ -110,12 +141,16 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			Ctx:    b.config.ctx,
 			VMName: b.config.VMName,
 		},
+		&common.StepPostInstallBootOrder{
+			Config: &b.config.RunConfig,
+		},
 	)
 
 	if b.config.Comm.Type != "none" {
 		steps = append(steps,
 			&common.StepWaitForIp{
-				Config: &b.config.WaitIpConfig,
+				Config:            &b.config.WaitIpConfig,
+				HeartbeatInterval: b.config.HeartbeatInterval,
 			},
 			&communicator.StepConnect{
 				Config:    &b.config.Comm,
@@ -124,11 +159,18 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			},
 			&commonsteps.StepProvision{},
 		)
+		if b.config.WindowsSysprep != nil {
+			steps = append(steps, &common.StepWindowsSysprep{
+				Config:            b.config.WindowsSysprep,
+				HeartbeatInterval: b.config.HeartbeatInterval,
+			})
+		}
 	}
 
 	steps = append(steps,
 		&common.StepShutdown{
-			Config: &b.config.ShutdownConfig,
+			Config:            &b.config.ShutdownConfig,
+			HeartbeatInterval: b.config.HeartbeatInterval,
 		},
 		&common.StepRemoveFloppy{
 			Datastore: b.config.Datastore,
@@ -141,6 +183,9 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			Config:      &b.config.ReattachCDRomConfig,
 			CDRomConfig: &b.config.CDRomConfig,
 		},
+		&common.StepRemoveBuildMarker{
+			Config: &b.config.OrphanedVMConfig,
+		},
 		&common.StepCreateSnapshot{
 			CreateSnapshot: b.config.CreateSnapshot,
 			SnapshotName:   b.config.SnapshotName,
@@ -158,12 +203,14 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 
 	if b.config.Export != nil {
 		steps = append(steps, &common.StepExport{
-			Name:       b.config.Export.Name,
-			Force:      b.config.Export.Force,
-			ImageFiles: b.config.Export.ImageFiles,
-			Manifest:   b.config.Export.Manifest,
-			OutputDir:  b.config.Export.OutputDir.OutputDir,
-			Options:    b.config.Export.Options,
+			Name:              b.config.Export.Name,
+			Force:             b.config.Export.Force,
+			ImageFiles:        b.config.Export.ImageFiles,
+			Manifest:          b.config.Export.Manifest,
+			OutputDir:         b.config.Export.OutputDir.OutputDir,
+			Options:           b.config.Export.Options,
+			Product:           b.config.Export.Product,
+			HeartbeatInterval: b.config.HeartbeatInterval,
 		})
 	}
 
@@ -179,6 +226,12 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 	}
 
 	vm := state.Get("vm").(*driver.VirtualMachineDriver)
+	isoPath := state.Get("iso_path")
+	if b.config.ISOContentLibrary != "" {
+		// The ISO was not downloaded by Packer, it is identified by its
+		// content library path.
+		isoPath = state.Get("iso_remote_path")
+	}
 	artifact := &common.Artifact{
 		Name:                 b.config.VMName,
 		Datacenter:           vm.Datacenter(),
@@ -189,7 +242,7 @@ func (b *Builder) Run(ctx context.Context, ui packersdk.Ui, hook packersdk.Hook)
 			"generated_data": state.Get("generated_data"),
 			"metadata":       state.Get("metadata"),
 			"SourceImageURL": state.Get("SourceImageURL"),
-			"iso_path":       state.Get("iso_path"),
+			"iso_path":       isoPath,
 		},
 	}
 
@@ -24,10 +24,12 @@ type Config struct {
 	common.ConnectConfig       `mapstructure:",squash"`
 	CreateConfig               `mapstructure:",squash"`
 	common.LocationConfig      `mapstructure:",squash"`
+	common.OrphanedVMConfig    `mapstructure:",squash"`
 	common.HardwareConfig      `mapstructure:",squash"`
 	common.ConfigParamsConfig  `mapstructure:",squash"`
 	common.FlagConfig          `mapstructure:",squash"`
 	commonsteps.ISOConfig      `mapstructure:",squash"`
+	common.RemoteISOConfig     `mapstructure:",squash"`
 	common.CDRomConfig         `mapstructure:",squash"`
 	common.RemoveCDRomConfig   `mapstructure:",squash"`
 	common.ReattachCDRomConfig `mapstructure:",squash"`
@@ -37,7 +39,8 @@ type Config struct {
 	common.WaitIpConfig        `mapstructure:",squash"`
 	Comm                       communicator.Config `mapstructure:",squash"`
 
-	common.ShutdownConfig `mapstructure:",squash"`
+	common.ShutdownConfig  `mapstructure:",squash"`
+	common.HeartbeatConfig `mapstructure:",squash"`
 
 	// Create a snapshot when set to `true`, so the VM can be used as a base
 	// for linked clones. Defaults to `false`.
@@ -45,7 +48,8 @@ type Config struct {
 	// When `create_snapshot` is `true`, `snapshot_name` determines the name of the snapshot.
 	// Defaults to `Created By Packer`.
 	SnapshotName string `mapstructure:"snapshot_name"`
-	// Convert VM to a template. Defaults to `false`.
+	// Convert VM to a template. Defaults to `false`. Not supported when
+	// connected to a standalone ESXi host.
 	ConvertToTemplate bool `mapstructure:"convert_to_template"`
 	// Configuration for exporting VM to an ovf file.
 	// The VM will not be exported if no [Export Configuration](#export-configuration) is specified.
@@ -54,6 +58,10 @@ type Config struct {
 	// The VM template will not be imported if no [Content Library Import Configuration](#content-library-import-configuration) is specified.
 	// The import doesn't work if [convert_to_template](#convert_to_template) is set to true.
 	ContentLibraryDestinationConfig *common.ContentLibraryDestinationConfig `mapstructure:"content_library_destination"`
+	// Configuration for generalizing a Windows guest with sysprep after the provisioners have run.
+	// The guest is not generalized if no [Windows Sysprep Configuration](#windows-sysprep-configuration) is specified.
+	// Requires a `communicator`.
+	WindowsSysprep *common.WindowsSysprepConfig `mapstructure:"windows_sysprep"`
 
 	ctx interpolate.Context
 }
@@ -81,19 +89,24 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 		warnings = append(warnings, isoWarnings...)
 		errs = packersdk.MultiErrorAppend(errs, isoErrs...)
 	}
+	errs = packersdk.MultiErrorAppend(errs, c.RemoteISOConfig.Prepare(c.ISOUrls, c.ISOChecksum)...)
 
 	errs = packersdk.MultiErrorAppend(errs, c.ConnectConfig.Prepare()...)
 	errs = packersdk.MultiErrorAppend(errs, c.CreateConfig.Prepare()...)
 	errs = packersdk.MultiErrorAppend(errs, c.LocationConfig.Prepare()...)
+	errs = packersdk.MultiErrorAppend(errs, c.OrphanedVMConfig.Prepare(&c.LocationConfig)...)
 	errs = packersdk.MultiErrorAppend(errs, c.HardwareConfig.Prepare()...)
 	errs = packersdk.MultiErrorAppend(errs, c.FlagConfig.Prepare(&c.HardwareConfig)...)
 	errs = packersdk.MultiErrorAppend(errs, c.HTTPConfig.Prepare(&c.ctx)...)
 	errs = packersdk.MultiErrorAppend(errs, c.CDRomConfig.Prepare(&c.ReattachCDRomConfig)...)
 	errs = packersdk.MultiErrorAppend(errs, c.CDConfig.Prepare(&c.ctx)...)
+	errs = packersdk.MultiErrorAppend(errs, c.RunConfig.Prepare()...)
 	errs = packersdk.MultiErrorAppend(errs, c.BootConfig.Prepare(&c.ctx)...)
 	errs = packersdk.MultiErrorAppend(errs, c.WaitIpConfig.Prepare()...)
 	errs = packersdk.MultiErrorAppend(errs, c.Comm.Prepare(&c.ctx)...)
 
+	errs = packersdk.MultiErrorAppend(errs, c.HeartbeatConfig.Prepare()...)
+
 	shutdownWarnings, shutdownErrs := c.ShutdownConfig.Prepare(c.Comm)
 	warnings = append(warnings, shutdownWarnings...)
 	errs = packersdk.MultiErrorAppend(errs, shutdownErrs...)
@@ -104,6 +117,9 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 	if c.ContentLibraryDestinationConfig != nil {
 		errs = packersdk.MultiErrorAppend(errs, c.ContentLibraryDestinationConfig.Prepare(&c.LocationConfig)...)
 	}
+	if c.WindowsSysprep != nil {
+		errs = packersdk.MultiErrorAppend(errs, c.WindowsSysprep.Prepare(c.Comm)...)
+	}
 
 	if len(errs.Errors) > 0 {
 		return warnings, errs
@@ -132,6 +132,7 @@ type StepCreateVM struct {
 	Config        *CreateConfig
 	Location      *common.LocationConfig
 	Force         bool
+	BuildMarker   bool
 	GeneratedData *packerbuilderdata.GeneratedData
 }
 
@@ -162,11 +163,21 @@ func (s *StepCreateVM) Run(_ context.Context, state multistep.StateBag) multiste
 	// add disk as the first drive for backwards compatibility if the type is defined
 	var disks []driver.Disk
 	for _, disk := range s.Config.StorageConfig.Storage {
+		var policyID string
+		if disk.DiskEncryptionPolicy != "" {
+			policyID, err = d.FindStoragePolicyID(disk.DiskEncryptionPolicy)
+			if err != nil {
+				state.Put("error", fmt.Errorf("error finding storage policy %s: %s", disk.DiskEncryptionPolicy, err))
+				return multistep.ActionHalt
+			}
+		}
 		disks = append(disks, driver.Disk{
 			DiskSize:            disk.DiskSize,
 			DiskEagerlyScrub:    disk.DiskEagerlyScrub,
 			DiskThinProvisioned: disk.DiskThinProvisioned,
 			ControllerIndex:     disk.DiskControllerIndex,
+			EncryptionPolicyID:  policyID,
+			KeyProvider:         disk.DiskKeyProvider,
 		})
 	}
 
@@ -186,6 +197,7 @@ func (s *StepCreateVM) Run(_ context.Context, state multistep.StateBag) multiste
 		NICs:          networkCards,
 		USBController: s.Config.USBController,
 		Version:       s.Config.Version,
+		BuildMarker:   s.BuildMarker,
 	})
 	if err != nil {
 		state.Put("error", fmt.Errorf("error creating vm: %v", err))
@@ -77,6 +77,21 @@ func TestCreateConfig_Prepare(t *testing.T) {
 			fail:           true,
 			expectedErrMsg: "storage[0].'disk_controller_index' references an unknown disk controller",
 		},
+		{
+			name: "Storage validate disk_key_provider is set with disk_encryption_policy",
+			config: &CreateConfig{
+				StorageConfig: common.StorageConfig{
+					Storage: []common.DiskConfig{
+						{
+							DiskSize:             32768,
+							DiskEncryptionPolicy: "VM Encryption Policy",
+						},
+					},
+				},
+			},
+			fail:           true,
+			expectedErrMsg: "storage[0].'disk_encryption_policy' and storage[0].'disk_key_provider' must be set together",
+		},
 		{
 			name: "USBController validate 'usb' and 'xhci' can be set together",
 			config: &CreateConfig{
@@ -236,6 +251,42 @@ func TestStepCreateVM_Run(t *testing.T) {
 	}
 }
 
+func TestStepCreateVM_RunEncryptedDisk(t *testing.T) {
+	state := basicStateBag()
+	driverMock := driver.NewDriverMock()
+	driverMock.FindStoragePolicyIDResponse = "policy-id"
+	state.Put("driver", driverMock)
+	step := basicStepCreateVM()
+	step.Config.StorageConfig.Storage[0].DiskEncryptionPolicy = "VM Encryption Policy"
+	step.Config.StorageConfig.Storage[0].DiskKeyProvider = "nkp"
+
+	if action := step.Run(context.TODO(), state); action == multistep.ActionHalt {
+		t.Fatalf("Should not halt.")
+	}
+
+	if !driverMock.FindStoragePolicyIDCalled {
+		t.Fatalf("driver.FindStoragePolicyID should be called.")
+	}
+	if driverMock.FindStoragePolicyIDName != "VM Encryption Policy" {
+		t.Fatalf("Storage policy expected to be 'VM Encryption Policy' but was %s", driverMock.FindStoragePolicyIDName)
+	}
+	disk := driverMock.CreateConfig.StorageConfig.Storage[0]
+	if disk.EncryptionPolicyID != "policy-id" || disk.KeyProvider != "nkp" {
+		t.Fatalf("Disk should be encrypted with 'policy-id' and 'nkp' but was %s and %s", disk.EncryptionPolicyID, disk.KeyProvider)
+	}
+
+	// FindStoragePolicyID fails
+	driverMock = driver.NewDriverMock()
+	driverMock.FindStoragePolicyIDErr = errors.New("not found")
+	state.Put("driver", driverMock)
+	if action := step.Run(context.TODO(), state); action != multistep.ActionHalt {
+		t.Fatalf("Step should halt.")
+	}
+	if driverMock.CreateVMCalled {
+		t.Fatalf("driver.CreateVM should not be called")
+	}
+}
+
 func TestStepCreateVM_RunHalt(t *testing.T) {
 	state := basicStateBag()
 	step := basicStepCreateVM()
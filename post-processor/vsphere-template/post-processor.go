@@ -53,6 +53,8 @@ type Config struct {
 	SnapshotDescription string         `mapstructure:"snapshot_description"`
 	ReregisterVM        config.Trilean `mapstructure:"reregister_vm"`
 
+	vsphere.HeartbeatConfig `mapstructure:",squash"`
+
 	ctx interpolate.Context
 }
 
@@ -91,6 +93,8 @@ func (p *PostProcessor) Configure(raws ...interface{}) error {
 		}
 	}
 
+	errs = packersdk.MultiErrorAppend(errs, p.config.HeartbeatConfig.Prepare()...)
+
 	sdk, err := url.Parse(fmt.Sprintf("https://%v/sdk", p.config.Host))
 	if err != nil {
 		errs = packersdk.MultiErrorAppend(
@@ -5,6 +5,7 @@ package vsphere_template
 
 import (
 	"testing"
+	"time"
 )
 
 func getTestConfig() Config {
@@ -40,3 +41,18 @@ func TestConfigure_ReRegisterVM(t *testing.T) {
 		t.Errorf("This should default to unset, not false.")
 	}
 }
+
+func TestConfigure_HeartbeatInterval(t *testing.T) {
+	var p PostProcessor
+
+	config := getTestConfig()
+
+	err := p.Configure(config)
+	if err != nil {
+		t.Errorf("Error: %s", err)
+	}
+
+	if p.config.HeartbeatInterval != time.Minute {
+		t.Errorf("This should default to 1m, not %s.", p.config.HeartbeatInterval)
+	}
+}
@@ -6,9 +6,11 @@ package vsphere_template
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
+	vspherecommon "github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/common"
 	"github.com/hashicorp/packer-plugin-vsphere/post-processor/vsphere"
 	"github.com/vmware/govmomi"
 )
@@ -19,6 +21,7 @@ type stepCreateSnapshot struct {
 	SnapshotName        string
 	SnapshotDescription string
 	SnapshotEnable      bool
+	HeartbeatInterval   time.Duration
 }
 
 func NewStepCreateSnapshot(artifact packersdk.Artifact, p *PostProcessor) *stepCreateSnapshot {
@@ -37,6 +40,7 @@ func NewStepCreateSnapshot(artifact packersdk.Artifact, p *PostProcessor) *stepC
 		SnapshotEnable:      p.config.SnapshotEnable,
 		SnapshotName:        p.config.SnapshotName,
 		SnapshotDescription: p.config.SnapshotDescription,
+		HeartbeatInterval:   p.config.HeartbeatInterval,
 	}
 }
 
@@ -67,7 +71,10 @@ func (s *stepCreateSnapshot) Run(ctx context.Context, state multistep.StateBag)
 		return multistep.ActionHalt
 	}
 
-	if err = task.Wait(context.Background()); err != nil {
+	stop := vspherecommon.StartHeartbeat(ui, s.HeartbeatInterval, "creating snapshot")
+	err = task.Wait(context.Background())
+	stop()
+	if err != nil {
 		state.Put("error", err)
 		ui.Error(err.Error())
 		return multistep.ActionHalt
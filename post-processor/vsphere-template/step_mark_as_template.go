@@ -9,10 +9,12 @@ import (
 	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/packer-plugin-sdk/multistep"
 	packersdk "github.com/hashicorp/packer-plugin-sdk/packer"
 	"github.com/hashicorp/packer-plugin-sdk/template/config"
+	vspherecommon "github.com/hashicorp/packer-plugin-vsphere/builder/vsphere/common"
 	"github.com/hashicorp/packer-plugin-vsphere/post-processor/vsphere"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
@@ -20,9 +22,10 @@ import (
 )
 
 type stepMarkAsTemplate struct {
-	VMName       string
-	RemoteFolder string
-	ReregisterVM config.Trilean
+	VMName            string
+	RemoteFolder      string
+	ReregisterVM      config.Trilean
+	HeartbeatInterval time.Duration
 }
 
 func NewStepMarkAsTemplate(artifact packersdk.Artifact, p *PostProcessor) *stepMarkAsTemplate {
@@ -40,9 +43,10 @@ func NewStepMarkAsTemplate(artifact packersdk.Artifact, p *PostProcessor) *stepM
 	}
 
 	return &stepMarkAsTemplate{
-		VMName:       vmname,
-		RemoteFolder: remoteFolder,
-		ReregisterVM: p.config.ReregisterVM,
+		VMName:            vmname,
+		RemoteFolder:      remoteFolder,
+		ReregisterVM:      p.config.ReregisterVM,
+		HeartbeatInterval: p.config.HeartbeatInterval,
 	}
 }
 
@@ -62,6 +66,8 @@ func (s *stepMarkAsTemplate) Run(ctx context.Context, state multistep.StateBag)
 	// Use a simple "MarkAsTemplate" method unless `reregister_vm` is true
 	if s.ReregisterVM.False() {
 		ui.Message("Marking as a template...")
+		stop := vspherecommon.StartHeartbeat(ui, s.HeartbeatInterval, "marking as a template")
+		defer stop()
 
 		if err := vm.MarkAsTemplate(context.Background()); err != nil {
 			state.Put("error", err)
@@ -72,6 +78,8 @@ func (s *stepMarkAsTemplate) Run(ctx context.Context, state multistep.StateBag)
 	}
 
 	ui.Message("Re-register VM as a template...")
+	stop := vspherecommon.StartHeartbeat(ui, s.HeartbeatInterval, "re-registering VM as a template")
+	defer stop()
 
 	dsPath, err := datastorePath(vm)
 	if err != nil {